@@ -0,0 +1,147 @@
+package disgo
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// AuditLog is a single page of the audit log of a guild, along with the users and webhooks its entries refer to
+type AuditLog struct {
+	Webhooks []*Webhook       `json:"webhooks"`
+	Users    []*User          `json:"users"`
+	Entries  []*AuditLogEntry `json:"audit_log_entries"`
+}
+
+// User returns the user with the given ID if it is part of this page of the audit log
+func (l *AuditLog) User(id Snowflake) (*User, bool) {
+	for _, user := range l.Users {
+		if user.ID() == id {
+			return user, true
+		}
+	}
+
+	return nil, false
+}
+
+// Webhook returns the webhook with the given ID if it is part of this page of the audit log
+func (l *AuditLog) Webhook(id Snowflake) (*Webhook, bool) {
+	for _, webhook := range l.Webhooks {
+		if webhook.ID() == id {
+			return webhook, true
+		}
+	}
+
+	return nil, false
+}
+
+// AuditLogFilter limits which entries are returned from the audit log, any zero field is ignored
+type AuditLogFilter struct {
+	UserID     Snowflake
+	ActionType AuditLogAction
+	Before     Snowflake
+	Limit      int
+}
+
+func (s *Session) GetAuditLog(guildID Snowflake, filter AuditLogFilter, options ...RequestOption) (*AuditLog, error) {
+	query := url.Values{}
+	if filter.UserID != 0 {
+		query.Set("user_id", filter.UserID.String())
+	}
+	if filter.ActionType != 0 {
+		query.Set("action_type", strconv.Itoa(int(filter.ActionType)))
+	}
+	if filter.Before != 0 {
+		query.Set("before", filter.Before.String())
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(clampLimit(filter.Limit, 1, 100)))
+	}
+
+	endPoint := EndPointGuildAuditLogs(guildID)
+	if len(query) != 0 {
+		endPoint.Url += "?" + query.Encode()
+	}
+
+	auditLog := &AuditLog{}
+	if err := s.doHttpGet(endPoint, auditLog, options); err != nil {
+		return nil, err
+	}
+
+	for i, user := range auditLog.Users {
+		user = objects.registerUser(user)
+		if user.session == nil {
+			user.session = s
+		}
+		auditLog.Users[i] = user
+	}
+
+	return auditLog, nil
+}
+
+func (s *Guild) GetAuditLog(filter AuditLogFilter, options ...RequestOption) (*AuditLog, error) {
+	return s.session.GetAuditLog(s.internal.ID, filter, options...)
+}
+
+// AuditLogIterator walks through the audit log of a guild from the newest to the oldest entry.
+// Iterators fetch pages as they are needed, Next returns false once there are no more items or an error occurred,
+// which Err returns afterwards.
+//
+//	iterator := session.IterateAuditLog(guildID, disgo.AuditLogFilter{})
+//	for iterator.Next() {
+//		entry := iterator.Entry()
+//	}
+//	if err := iterator.Err(); err != nil {
+//	}
+type AuditLogIterator struct {
+	session *Session
+	guildID Snowflake
+	filter  AuditLogFilter
+	options []RequestOption
+
+	page  *AuditLog
+	pager pager
+}
+
+// IterateAuditLog creates an iterator over the audit log of a guild.
+// The Limit of the filter caps the total amount of entries returned, 0 walks through the full history.
+func (s *Session) IterateAuditLog(guildID Snowflake, filter AuditLogFilter, options ...RequestOption) *AuditLogIterator {
+	iterator := &AuditLogIterator{session: s, guildID: guildID, filter: filter, options: options}
+	iterator.pager = newPager(100, filter.Limit, iterator.fetch)
+	return iterator
+}
+
+func (s *Guild) IterateAuditLog(filter AuditLogFilter, options ...RequestOption) *AuditLogIterator {
+	return s.session.IterateAuditLog(s.internal.ID, filter, options...)
+}
+
+func (i *AuditLogIterator) Next() bool {
+	return i.pager.next()
+}
+
+func (i *AuditLogIterator) fetch(limit int) (int, error) {
+	filter := i.filter
+	filter.Limit = limit
+
+	page, err := i.session.GetAuditLog(i.guildID, filter, i.options...)
+	if err != nil || len(page.Entries) == 0 {
+		return 0, err
+	}
+
+	i.page = page
+	i.filter.Before = page.Entries[len(page.Entries)-1].ID()
+	return len(page.Entries), nil
+}
+
+// Entry returns the entry the iterator is currently at
+func (i *AuditLogIterator) Entry() *AuditLogEntry {
+	return i.page.Entries[i.pager.index]
+}
+
+// Page returns the page the current entry is part of, which holds the users and webhooks it refers to
+func (i *AuditLogIterator) Page() *AuditLog {
+	return i.page
+}
+
+func (i *AuditLogIterator) Err() error {
+	return i.pager.err
+}
@@ -0,0 +1,60 @@
+package disgo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestAuditLogIterator(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		// 150 entries with IDs 150 down to 1, served in pages
+		before := 151
+		if r.URL.Query().Get("before") != "" {
+			before, _ = strconv.Atoi(r.URL.Query().Get("before"))
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		fmt.Fprint(w, `{"users": [{"id": "7", "username": "mod"}], "webhooks": [], "audit_log_entries": [`)
+		for id, written := before-1, 0; id > 0 && written < limit; id, written = id-1, written+1 {
+			if written > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": "%d", "user_id": "7", "target_id": "3", "action_type": 22, "reason": "spam", "changes": [{"key": "nick", "old_value": "a", "new_value": "b"}]}`, id)
+		}
+		fmt.Fprint(w, `]}`)
+	})
+
+	iterator := session.IterateAuditLog(1, AuditLogFilter{ActionType: AuditLogActionMemberBanAdd})
+	count := 0
+	for iterator.Next() {
+		count++
+		entry := iterator.Entry()
+		if entry.ID() != Snowflake(151-count) || entry.ActionType() != AuditLogActionMemberBanAdd {
+			t.Fatalf("unexpected entry %d: %+v", count, entry.internal)
+		}
+		if user, exists := iterator.Page().User(entry.UserID()); !exists || user.Username() != "mod" {
+			t.Fatal("the user of the entry is not part of its page")
+		}
+
+		var nick string
+		if err := entry.Changes()[0].DecodeNew(&nick); err != nil || nick != "b" {
+			t.Fatalf("could not decode the change: %v", err)
+		}
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 150 {
+		t.Fatalf("expected 150 entries, got %d", count)
+	}
+	recorder.expect(t,
+		"GET /guilds/1/audit-logs?action_type=22&limit=100",
+		"GET /guilds/1/audit-logs?action_type=22&before=51&limit=100",
+	)
+}
@@ -1,5 +1,7 @@
 package disgo
 
+import "encoding/json"
+
 type ChannelBuilder struct {
 	session *Session
 	guildID Snowflake
@@ -31,9 +33,9 @@ func (b *ChannelBuilder) AddRoleOverwrite(id Snowflake, allow, deny int) *Channe
 	return b
 }
 
-func (b *ChannelBuilder) Create() (*Channel, error) {
+func (b *ChannelBuilder) Create(options ...RequestOption) (*Channel, error) {
 	channel := &Channel{}
-	err := b.session.doHttpPost(EndPointGuildChannels(b.guildID), b, channel)
+	err := b.session.doHttpPost(EndPointGuildChannels(b.guildID), b, channel, options)
 	if err != nil {
 		return nil, err
 	}
@@ -43,3 +45,329 @@ func (b *ChannelBuilder) Create() (*Channel, error) {
 	}
 	return channel, nil
 }
+
+type ChannelEditBuilder struct {
+	session   *Session
+	channelID Snowflake
+
+	changes map[string]interface{}
+}
+
+func (b *ChannelEditBuilder) Name(name string) *ChannelEditBuilder {
+	b.changes["name"] = name
+	return b
+}
+
+func (b *ChannelEditBuilder) Topic(topic string) *ChannelEditBuilder {
+	b.changes["topic"] = topic
+	return b
+}
+
+func (b *ChannelEditBuilder) Bitrate(bitrate int) *ChannelEditBuilder {
+	b.changes["bitrate"] = bitrate
+	return b
+}
+
+func (b *ChannelEditBuilder) UserLimit(userLimit int) *ChannelEditBuilder {
+	b.changes["user_limit"] = userLimit
+	return b
+}
+
+func (b *ChannelEditBuilder) Position(position int) *ChannelEditBuilder {
+	b.changes["position"] = position
+	return b
+}
+
+func (b *ChannelEditBuilder) NSFW(nsfw bool) *ChannelEditBuilder {
+	b.changes["nsfw"] = nsfw
+	return b
+}
+
+// PermissionOverwrites replaces all permission overwrites of the channel
+func (b *ChannelEditBuilder) PermissionOverwrites(overwrites []Overwrite) *ChannelEditBuilder {
+	if overwrites == nil {
+		overwrites = make([]Overwrite, 0)
+	}
+	b.changes["permission_overwrites"] = overwrites
+	return b
+}
+
+// Apply sends the changes to Discord, the cached channel is updated with the channel Discord returns
+func (b *ChannelEditBuilder) Apply(options ...RequestOption) (*Channel, error) {
+	channel := &Channel{}
+	err := b.session.doHttpPatch(EndPointChannel(b.channelID), b.changes, channel, options)
+	if err != nil {
+		return nil, err
+	}
+	channel = objects.registerChannel(channel)
+	if channel.session == nil {
+		channel.session = b.session
+	}
+	return channel, nil
+}
+
+type InviteBuilder struct {
+	session   *Session
+	channelID Snowflake
+
+	// MaxAge is the amount of seconds the invite is valid for, 0 never expires
+	MaxAge int `json:"max_age"`
+	// MaxUses is the amount of times the invite can be used, 0 is unlimited
+	MaxUses int `json:"max_uses"`
+	// Temporary invites give membership that is revoked once the user disconnects, unless they were given a role
+	Temporary bool `json:"temporary"`
+	// Unique always creates a new invite, instead of reusing a similar one
+	Unique bool `json:"unique"`
+}
+
+func (b *InviteBuilder) Create(options ...RequestOption) (*Invite, error) {
+	invite := &Invite{}
+	err := b.session.doHttpPost(EndPointChannelInvites(b.channelID), b, invite, options)
+	if err != nil {
+		return nil, err
+	}
+	invite.session = b.session
+	return invite, nil
+}
+
+type RoleBuilder struct {
+	session *Session
+	guildID Snowflake
+
+	Name        string `json:"name"`
+	Permissions int    `json:"permissions"`
+	Color       int    `json:"color"`
+	Hoist       bool   `json:"hoist"`
+	Mentionable bool   `json:"mentionable"`
+}
+
+func (b *RoleBuilder) Create(options ...RequestOption) (*Role, error) {
+	role := &Role{}
+	err := b.session.doHttpPost(EndPointGuildRoles(b.guildID), b, role, options)
+	if err != nil {
+		return nil, err
+	}
+	role = b.session.registerGuildRole(b.guildID, role)
+	addCachedGuildRole(b.guildID, role)
+	return role, nil
+}
+
+type RoleEditBuilder struct {
+	session *Session
+	guildID Snowflake
+	roleID  Snowflake
+
+	changes map[string]interface{}
+}
+
+func (b *RoleEditBuilder) Name(name string) *RoleEditBuilder {
+	b.changes["name"] = name
+	return b
+}
+
+func (b *RoleEditBuilder) Permissions(permissions int) *RoleEditBuilder {
+	b.changes["permissions"] = permissions
+	return b
+}
+
+func (b *RoleEditBuilder) Color(color int) *RoleEditBuilder {
+	b.changes["color"] = color
+	return b
+}
+
+func (b *RoleEditBuilder) Hoist(hoist bool) *RoleEditBuilder {
+	b.changes["hoist"] = hoist
+	return b
+}
+
+func (b *RoleEditBuilder) Mentionable(mentionable bool) *RoleEditBuilder {
+	b.changes["mentionable"] = mentionable
+	return b
+}
+
+// Apply sends the changes to Discord, the cached role is updated with the role Discord returns
+func (b *RoleEditBuilder) Apply(options ...RequestOption) (*Role, error) {
+	role := &Role{}
+	err := b.session.doHttpPatch(EndPointGuildRole(b.guildID, b.roleID), b.changes, role, options)
+	if err != nil {
+		return nil, err
+	}
+	return b.session.registerGuildRole(b.guildID, role), nil
+}
+
+type GuildBuilder struct {
+	session *Session
+
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+	// Icon is the data URI of the image, use ImageData to create one
+	Icon                        string `json:"icon,omitempty"`
+	VerificationLevel           int    `json:"verification_level"`
+	DefaultMessageNotifications int    `json:"default_message_notifications"`
+}
+
+func (b *GuildBuilder) Create(options ...RequestOption) (*Guild, error) {
+	body := json.RawMessage{}
+	err := b.session.doHttpPost(EndPointGuilds(), b, &body, options)
+	if err != nil {
+		return nil, err
+	}
+	return b.session.registerGuildResponse(body)
+}
+
+type GuildEditBuilder struct {
+	session *Session
+	guildID Snowflake
+
+	changes map[string]interface{}
+}
+
+func (b *GuildEditBuilder) Name(name string) *GuildEditBuilder {
+	b.changes["name"] = name
+	return b
+}
+
+func (b *GuildEditBuilder) Region(region string) *GuildEditBuilder {
+	b.changes["region"] = region
+	return b
+}
+
+func (b *GuildEditBuilder) VerificationLevel(level int) *GuildEditBuilder {
+	b.changes["verification_level"] = level
+	return b
+}
+
+func (b *GuildEditBuilder) DefaultMessageNotifications(notifications int) *GuildEditBuilder {
+	b.changes["default_message_notifications"] = notifications
+	return b
+}
+
+// AFKChannel sets the voice channel members are moved to when idle, 0 removes it
+func (b *GuildEditBuilder) AFKChannel(channelID Snowflake) *GuildEditBuilder {
+	if channelID == 0 {
+		b.changes["afk_channel_id"] = nil
+	} else {
+		b.changes["afk_channel_id"] = channelID
+	}
+	return b
+}
+
+// AFKTimeout sets the seconds after which idle members are moved to the AFK channel
+func (b *GuildEditBuilder) AFKTimeout(seconds int) *GuildEditBuilder {
+	b.changes["afk_timeout"] = seconds
+	return b
+}
+
+// Icon sets the icon of the guild to the data URI created by ImageData, an empty string removes it
+func (b *GuildEditBuilder) Icon(imageData string) *GuildEditBuilder {
+	b.changes["icon"] = nullableString(imageData)
+	return b
+}
+
+// Splash sets the invite splash of the guild to the data URI created by ImageData, an empty string removes it
+func (b *GuildEditBuilder) Splash(imageData string) *GuildEditBuilder {
+	b.changes["splash"] = nullableString(imageData)
+	return b
+}
+
+// Owner transfers the ownership of the guild to another member, only the current owner can do this
+func (b *GuildEditBuilder) Owner(userID Snowflake) *GuildEditBuilder {
+	b.changes["owner_id"] = userID
+	return b
+}
+
+// Apply sends the changes to Discord, the cached guild is updated with the guild Discord returns
+func (b *GuildEditBuilder) Apply(options ...RequestOption) (*Guild, error) {
+	body := json.RawMessage{}
+	err := b.session.doHttpPatch(EndPointGuild(b.guildID), b.changes, &body, options)
+	if err != nil {
+		return nil, err
+	}
+	return b.session.registerGuildResponse(body)
+}
+
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+type GuildMemberEditBuilder struct {
+	session *Session
+	guildID Snowflake
+	userID  Snowflake
+
+	changes map[string]interface{}
+}
+
+// Nick changes the nickname of the member, an empty string removes it
+func (b *GuildMemberEditBuilder) Nick(nick string) *GuildMemberEditBuilder {
+	b.changes["nick"] = nick
+	return b
+}
+
+// Roles replaces all roles of the member at once
+func (b *GuildMemberEditBuilder) Roles(roleIDs []Snowflake) *GuildMemberEditBuilder {
+	if roleIDs == nil {
+		roleIDs = make([]Snowflake, 0)
+	}
+	b.changes["roles"] = roleIDs
+	return b
+}
+
+func (b *GuildMemberEditBuilder) Mute(mute bool) *GuildMemberEditBuilder {
+	b.changes["mute"] = mute
+	return b
+}
+
+func (b *GuildMemberEditBuilder) Deaf(deaf bool) *GuildMemberEditBuilder {
+	b.changes["deaf"] = deaf
+	return b
+}
+
+// Move moves the member to another voice channel, this only works if they are connected to voice.
+// Moving them to channel 0 disconnects them, like Disconnect.
+func (b *GuildMemberEditBuilder) Move(channelID Snowflake) *GuildMemberEditBuilder {
+	if channelID == 0 {
+		return b.Disconnect()
+	}
+
+	b.changes["channel_id"] = channelID
+	return b
+}
+
+// Disconnect disconnects the member from voice
+func (b *GuildMemberEditBuilder) Disconnect() *GuildMemberEditBuilder {
+	b.changes["channel_id"] = nil
+	return b
+}
+
+// Apply sends the changes to Discord, and applies them to the cached member.
+// If the member is the bot itself, its nickname is changed through the endpoint that doesn't require the permission to manage nicknames.
+func (b *GuildMemberEditBuilder) Apply(options ...RequestOption) error {
+	changes := b.changes
+	if nick, changed := changes["nick"]; changed && b.userID == botID {
+		if err := b.session.SetOwnNick(b.guildID, nick.(string), options...); err != nil {
+			return err
+		}
+
+		changes = make(map[string]interface{}, len(b.changes))
+		for key, value := range b.changes {
+			if key != "nick" {
+				changes[key] = value
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
+	err := b.session.doHttpPatch(EndPointGuildMember(b.guildID, b.userID), changes, nil, options)
+	if err != nil {
+		return err
+	}
+
+	updateCachedGuildMember(b.guildID, b.userID, changes)
+	return nil
+}
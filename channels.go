@@ -6,6 +6,8 @@ import (
 	"io"
 	"math"
 	"mime/multipart"
+	"net/textproto"
+	"strings"
 )
 
 func (s *Session) BuildChannel(guildID Snowflake, name string) *ChannelBuilder {
@@ -30,12 +32,38 @@ func (s *Channel) Guild() *Guild {
 	return objects.guilds[s.internal.GuildID]
 }
 
-func (s *Session) DeleteChannel(channelID Snowflake) error {
-	return s.doHttpDelete(EndPointChannel(channelID), nil)
+func (s *Session) GetChannel(channelID Snowflake, options ...RequestOption) (*Channel, error) {
+	channel := &Channel{}
+	if err := s.doHttpGet(EndPointChannel(channelID), channel, options); err != nil {
+		return nil, err
+	}
+
+	channel = objects.registerChannel(channel)
+	if channel.session == nil {
+		channel.session = s
+	}
+	return channel, nil
+}
+
+// EditChannel creates a builder for changes to a channel, only the fields that are set on it are sent to Discord
+func (s *Session) EditChannel(channelID Snowflake) *ChannelEditBuilder {
+	return &ChannelEditBuilder{
+		session:   s,
+		channelID: channelID,
+		changes:   make(map[string]interface{}),
+	}
+}
+
+func (s *Channel) Edit() *ChannelEditBuilder {
+	return s.session.EditChannel(s.ID())
+}
+
+func (s *Session) DeleteChannel(channelID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointChannel(channelID), nil, options)
 }
 
-func (s *Channel) Delete() error {
-	return s.session.DeleteChannel(s.ID())
+func (s *Channel) Delete(options ...RequestOption) error {
+	return s.session.DeleteChannel(s.ID(), options...)
 }
 
 type MessagePrototype struct {
@@ -43,41 +71,137 @@ type MessagePrototype struct {
 	TTS     bool   `json:"tts"`
 	Embed   *Embed `json:"embed,omitempty"`
 
-	FileName string
-	File     io.Reader
+	// Files are uploaded along with the message, an embed can show one using MessageFile.AttachmentURL
+	Files []*MessageFile `json:"-"`
+
+	// Deprecated: FileName and File are sent as the first of Files, use Files instead
+	FileName string    `json:"-"`
+	File     io.Reader `json:"-"`
+}
+
+// MessageFile is a file that is uploaded along with a message
+type MessageFile struct {
+	Name        string
+	ContentType string
+	Spoiler     bool
+
+	// Reader supplies the contents of the file, if it is an io.Seeker it is rewound when the upload has to be retried.
+	// Otherwise, set Open instead to allow retrying the upload.
+	Reader io.Reader
+
+	// Open is called to read the contents of the file for every attempt at uploading it, if set, Reader is ignored
+	Open func() (io.ReadCloser, error)
+}
+
+// FileName returns the name the file is uploaded with, which is prefixed if it is a spoiler
+func (f *MessageFile) FileName() string {
+	if f.Spoiler && !strings.HasPrefix(f.Name, "SPOILER_") {
+		return "SPOILER_" + f.Name
+	}
+
+	return f.Name
+}
+
+// AttachmentURL returns the url an embed of the same message can use to refer to this file, for example as its image
+func (f *MessageFile) AttachmentURL() string {
+	return "attachment://" + f.FileName()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// fileUpload is a MessageFile as part of a single request, sending a file never changes the MessageFile itself
+type fileUpload struct {
+	*MessageFile
+
+	// offset is where the Reader was when the request started, a retry rewinds it to there
+	offset int64
 }
 
-func (s *Session) SendMessage(channelID Snowflake, content string) (*Message, error) {
-	return s.SendMessageP(channelID, MessagePrototype{Content: content})
+func (f *fileUpload) writeTo(writer *multipart.Writer, field string, retry bool) error {
+	var reader io.Reader
+	switch {
+	case f.Open != nil:
+		readCloser, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer readCloser.Close()
+		reader = readCloser
+	case !retry:
+		// Remember where we started, so we can rewind to it if we have to retry
+		if seeker, ok := f.Reader.(io.Seeker); ok {
+			offset, err := seeker.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return err
+			}
+			f.offset = offset
+		}
+		reader = f.Reader
+	default:
+		seeker, ok := f.Reader.(io.Seeker)
+		if !ok {
+			return fmt.Errorf("The upload of %s has to be retried, but its Reader can't be rewound. Use a Reader that implements io.Seeker, or use Open", f.Name)
+		}
+		if _, err := seeker.Seek(f.offset, io.SeekStart); err != nil {
+			return err
+		}
+		reader = f.Reader
+	}
+
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, field, quoteEscaper.Replace(f.FileName())))
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, reader)
+	return err
 }
 
-func (s *Session) SendEmbed(channelID Snowflake, embed *Embed) (*Message, error) {
-	return s.SendMessageP(channelID, MessagePrototype{Embed: embed})
+func (s *Session) SendMessage(channelID Snowflake, content string, options ...RequestOption) (*Message, error) {
+	return s.SendMessageP(channelID, MessagePrototype{Content: content}, options...)
 }
 
-func (s *Session) SendMessageP(channelID Snowflake, prototype MessagePrototype) (*Message, error) {
+func (s *Session) SendEmbed(channelID Snowflake, embed *Embed, options ...RequestOption) (*Message, error) {
+	return s.SendMessageP(channelID, MessagePrototype{Embed: embed}, options...)
+}
+
+func (s *Session) SendMessageP(channelID Snowflake, prototype MessagePrototype, options ...RequestOption) (*Message, error) {
 	message := &Message{}
 
-	var err error
-	if prototype.File == nil {
-		err = s.doHttpPost(EndPointMessages(channelID), &prototype, message)
-	} else {
+	files := prototype.Files
+	if prototype.File != nil {
 		if prototype.FileName == "" {
 			panic("A File was passed to a message without a FileName.")
 		}
 
-		var jsonPayload []byte
-		jsonPayload, err = json.Marshal(&prototype)
-		err = s.doHttMultipartPost(EndPointMessages(channelID), func(writer *multipart.Writer) error {
-			writer.WriteField("payload_json", string(jsonPayload))
-
-			if fileW, err := writer.CreateFormFile("file", prototype.FileName); err == nil {
-				io.Copy(fileW, prototype.File)
-				return nil
-			} else {
-				return err
+		files = append([]*MessageFile{{Name: prototype.FileName, Reader: prototype.File}}, files...)
+	}
+
+	var err error
+	if len(files) == 0 {
+		err = s.doHttpPost(EndPointMessages(channelID), &prototype, message, options)
+	} else {
+		for _, file := range files {
+			if file.Name == "" {
+				panic("A MessageFile was passed to a message without a Name.")
 			}
-		}, message)
+		}
+
+		var jsonPayload []byte
+		if jsonPayload, err = json.Marshal(&prototype); err != nil {
+			return nil, err
+		}
+
+		err = s.doHttMultipartPost(EndPointMessages(channelID), jsonPayload, files, message, options)
 	}
 
 	if err != nil {
@@ -95,21 +219,21 @@ type editMessageBody struct {
 	Embed   *Embed  `json:"embed,omitempty"`
 }
 
-func (s *Session) EditMessage(channelID, messageID Snowflake, content string) (*Message, error) {
-	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Content: &content})
+func (s *Session) EditMessage(channelID, messageID Snowflake, content string, options ...RequestOption) (*Message, error) {
+	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Content: &content}, options)
 }
 
-func (s *Session) EditEmbed(channelID, messageID Snowflake, embed Embed) (*Message, error) {
-	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Embed: &embed})
+func (s *Session) EditEmbed(channelID, messageID Snowflake, embed Embed, options ...RequestOption) (*Message, error) {
+	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Embed: &embed}, options)
 }
 
-func (s *Session) EditEmbeddedMessage(channelID, messageID Snowflake, content string, embed Embed) (*Message, error) {
-	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Content: &content, Embed: &embed})
+func (s *Session) EditEmbeddedMessage(channelID, messageID Snowflake, content string, embed Embed, options ...RequestOption) (*Message, error) {
+	return s.editMessageInternal(s.doHttpPatch, EndPointMessage(channelID, messageID), &editMessageBody{Content: &content, Embed: &embed}, options)
 }
 
-func (s *Session) editMessageInternal(method func(endPoint EndPoint, body, target interface{}) error, endpoint EndPoint, body *editMessageBody) (*Message, error) {
+func (s *Session) editMessageInternal(method func(endPoint EndPoint, body, target interface{}, options []RequestOption) error, endpoint EndPoint, body *editMessageBody, options []RequestOption) (*Message, error) {
 	message := &Message{}
-	err := method(endpoint, body, message)
+	err := method(endpoint, body, message, options)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +244,12 @@ func (s *Session) editMessageInternal(method func(endPoint EndPoint, body, targe
 	return message, nil
 }
 
-func (s *Session) GetMessage(channelID, messageID Snowflake) (*Message, error) {
+func (s *Session) GetMessage(channelID, messageID Snowflake, options ...RequestOption) (*Message, error) {
 	msg, exists := objects.messages[messageID]
 
 	if !exists {
 		msg = objects.registerMessage(&IDObject{messageID})
-		err := s.doHttpGet(EndPointMessage(channelID, messageID), msg)
+		err := s.doHttpGet(EndPointMessage(channelID, messageID), msg, options)
 
 		if err != nil {
 			objects.messageLock.Lock()
@@ -148,11 +272,11 @@ const (
 	GetMessagesAfter
 )
 
-func (s *Session) GetLastMessages(channelID Snowflake, limit int) ([]*Message, error) {
-	return s.GetMessages(channelID, GetLastMessages, 0, limit)
+func (s *Session) GetLastMessages(channelID Snowflake, limit int, options ...RequestOption) ([]*Message, error) {
+	return s.GetMessages(channelID, GetLastMessages, 0, limit, options...)
 }
 
-func (s *Session) GetMessages(channelID Snowflake, mode GetMessagesMode, target Snowflake, limit int) ([]*Message, error) {
+func (s *Session) GetMessages(channelID Snowflake, mode GetMessagesMode, target Snowflake, limit int, options ...RequestOption) ([]*Message, error) {
 	endPoint := EndPointMessages(channelID)
 	limit = int(math.Max(2, math.Min(float64(limit), 100)))
 
@@ -172,16 +296,17 @@ func (s *Session) GetMessages(channelID Snowflake, mode GetMessagesMode, target
 	endPoint.Url += fmt.Sprintf("&limit=%d", limit)
 	messages := make([]*Message, 0, limit)
 
-	err := s.doHttpGet(endPoint, &messages)
+	err := s.doHttpGet(endPoint, &messages, options)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, message := range messages {
-		objects.registerMessage(message)
+	for i, message := range messages {
+		message = objects.registerMessage(message)
 		if message.session == nil {
 			message.session = s
 		}
+		messages[i] = message
 	}
 
 	return messages, nil
@@ -194,82 +319,135 @@ func (s *Message) Channel() *Channel {
 	return objects.channels[s.internal.ChannelID]
 }
 
-func (s *Message) Edit(content string) (err error) {
-	_, err = s.session.EditMessage(s.internal.ChannelID, s.internal.ID, content)
+func (s *Message) Edit(content string, options ...RequestOption) (err error) {
+	_, err = s.session.EditMessage(s.internal.ChannelID, s.internal.ID, content, options...)
 	return
 }
 
-func (s *Message) EditEmbed(embed Embed) (err error) {
-	_, err = s.session.EditEmbed(s.internal.ChannelID, s.internal.ID, embed)
+func (s *Message) EditEmbed(embed Embed, options ...RequestOption) (err error) {
+	_, err = s.session.EditEmbed(s.internal.ChannelID, s.internal.ID, embed, options...)
 	return
 }
 
-func (s *Message) EditEmbeddedMessage(content string, embed Embed) (err error) {
-	_, err = s.session.EditEmbeddedMessage(s.internal.ChannelID, s.internal.ID, content, embed)
+func (s *Message) EditEmbeddedMessage(content string, embed Embed, options ...RequestOption) (err error) {
+	_, err = s.session.EditEmbeddedMessage(s.internal.ChannelID, s.internal.ID, content, embed, options...)
 	return
 }
 
-func (s *Session) DeleteMessage(channelID, messageID Snowflake) error {
+func (s *Session) DeleteMessage(channelID, messageID Snowflake, options ...RequestOption) error {
 	endPoint := EndPointMessage(channelID, messageID)
 	endPoint.bucket += "DELETE" // Deleting messages works with a separate rate limit to allow better moderation
-	return s.doHttpDelete(endPoint, nil)
+	return s.doHttpDelete(endPoint, nil, options)
 }
 
-func (s *Message) Delete() error {
-	return s.session.DeleteMessage(s.internal.ChannelID, s.internal.ID)
+func (s *Message) Delete(options ...RequestOption) error {
+	return s.session.DeleteMessage(s.internal.ChannelID, s.internal.ID, options...)
 }
 
-func (s *Session) BulkDeleteMessages(channelID Snowflake, ids []Snowflake) error {
+func (s *Session) BulkDeleteMessages(channelID Snowflake, ids []Snowflake, options ...RequestOption) error {
 	return s.doHttpPost(EndPointMessageBulkDelete(channelID), struct {
 		Messages []Snowflake `json:"messages"`
-	}{ids}, nil)
+	}{ids}, nil, options)
 }
 
-func (s *Session) PinMessage(channelID, messageID Snowflake) error {
-	return s.doHttpPut(EndPointChannelPin(channelID, messageID), nil)
+// GetPinnedMessages returns the pinned messages of a channel, the most recently pinned first
+func (s *Session) GetPinnedMessages(channelID Snowflake, options ...RequestOption) ([]*Message, error) {
+	messages := make([]*Message, 0)
+	if err := s.doHttpGet(EndPointChannelPins(channelID), &messages, options); err != nil {
+		return nil, err
+	}
+
+	for i, message := range messages {
+		message = objects.registerMessage(message)
+		if message.session == nil {
+			message.session = s
+		}
+		messages[i] = message
+	}
+
+	return messages, nil
+}
+
+func (s *Channel) Pins(options ...RequestOption) ([]*Message, error) {
+	return s.session.GetPinnedMessages(s.ID(), options...)
+}
+
+func (s *Session) PinMessage(channelID, messageID Snowflake, options ...RequestOption) error {
+	if err := s.doHttpPut(EndPointChannelPin(channelID, messageID), nil, nil, options); err != nil {
+		return err
+	}
+
+	setCachedPinned(messageID, true)
+	return nil
 }
 
-func (s *Message) Pin() error {
-	return s.session.PinMessage(s.internal.ChannelID, s.internal.ID)
+func (s *Message) Pin(options ...RequestOption) error {
+	return s.session.PinMessage(s.internal.ChannelID, s.internal.ID, options...)
+}
+
+func (s *Session) UnpinMessage(channelID, messageID Snowflake, options ...RequestOption) error {
+	if err := s.doHttpDelete(EndPointChannelPin(channelID, messageID), nil, options); err != nil {
+		return err
+	}
+
+	setCachedPinned(messageID, false)
+	return nil
+}
+
+func (s *Message) Unpin(options ...RequestOption) error {
+	return s.session.UnpinMessage(s.internal.ChannelID, s.internal.ID, options...)
+}
+
+// setCachedPinned updates the cached message, if we have it, as Discord doesn't return it when (un)pinning
+func setCachedPinned(messageID Snowflake, pinned bool) {
+	objects.messageLock.RLock()
+	message, exists := objects.messages[messageID]
+	objects.messageLock.RUnlock()
+
+	if exists {
+		message.lock.Lock()
+		message.internal.Pinned = pinned
+		message.lock.Unlock()
+	}
 }
 
-func (s *Session) MessageAddReaction(channelID, messageID Snowflake, emoji string) error {
+func (s *Session) MessageAddReaction(channelID, messageID Snowflake, emoji string, options ...RequestOption) error {
 	endPoint := EndPointOwnReaction(channelID, messageID)
 	endPoint.Url = fmt.Sprintf(endPoint.Url, emoji)
 	endPoint.resetTime = 300
-	return s.doHttpPut(endPoint, nil)
+	return s.doHttpPut(endPoint, nil, nil, options)
 }
 
-func (s *Message) AddReaction(emoji string) error {
-	return s.session.MessageAddReaction(s.internal.ChannelID, s.internal.ID, emoji)
+func (s *Message) AddReaction(emoji string, options ...RequestOption) error {
+	return s.session.MessageAddReaction(s.internal.ChannelID, s.internal.ID, emoji, options...)
 }
 
-func (s *Session) MessageDeleteOwnReaction(channelID, messageID Snowflake, emoji string) error {
+func (s *Session) MessageDeleteOwnReaction(channelID, messageID Snowflake, emoji string, options ...RequestOption) error {
 	endPoint := EndPointOwnReaction(channelID, messageID)
 	endPoint.Url = fmt.Sprintf(endPoint.Url, emoji)
 	endPoint.resetTime = 250
-	return s.doHttpDelete(endPoint, nil)
+	return s.doHttpDelete(endPoint, nil, options)
 }
 
-func (s *Session) MessageDeleteReaction(channelID, messageID, userID Snowflake, emoji string) error {
+func (s *Session) MessageDeleteReaction(channelID, messageID, userID Snowflake, emoji string, options ...RequestOption) error {
 	endPoint := EndPointReaction(channelID, messageID, userID)
 	endPoint.Url = fmt.Sprintf(endPoint.Url, emoji)
 	endPoint.resetTime = 250
-	return s.doHttpDelete(endPoint, nil)
+	return s.doHttpDelete(endPoint, nil, options)
 }
 
-func (s *Session) MessageDeleteAllReactions(channelID, messageID Snowflake) error {
-	return s.doHttpDelete(EndPointReactions(channelID, messageID), nil)
+func (s *Session) MessageDeleteAllReactions(channelID, messageID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointReactions(channelID, messageID), nil, options)
 }
 
-func (s *Message) DeleteReaction(userID Snowflake, emoji string) error {
-	return s.session.MessageDeleteReaction(s.internal.ChannelID, s.internal.ID, userID, emoji)
+func (s *Message) DeleteReaction(userID Snowflake, emoji string, options ...RequestOption) error {
+	return s.session.MessageDeleteReaction(s.internal.ChannelID, s.internal.ID, userID, emoji, options...)
 }
 
-func (s *Message) DeleteOwnReaction(emoji string) error {
-	return s.session.MessageDeleteOwnReaction(s.internal.ChannelID, s.internal.ID, emoji)
+func (s *Message) DeleteOwnReaction(emoji string, options ...RequestOption) error {
+	return s.session.MessageDeleteOwnReaction(s.internal.ChannelID, s.internal.ID, emoji, options...)
 }
 
-func (s *Message) DeleteAllReactions() error {
-	return s.session.MessageDeleteAllReactions(s.internal.ChannelID, s.internal.ID)
+func (s *Message) DeleteAllReactions(options ...RequestOption) error {
+	return s.session.MessageDeleteAllReactions(s.internal.ChannelID, s.internal.ID, options...)
 }
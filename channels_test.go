@@ -0,0 +1,191 @@
+package disgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSendMessageFiles(t *testing.T) {
+	var attempts int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("attempt %d: %s", atomic.LoadInt32(&attempts)+1, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if payload := r.FormValue("payload_json"); !strings.Contains(payload, `"url":"attachment://SPOILER_b.txt"`) {
+			t.Errorf("unexpected payload: %s", payload)
+		}
+
+		expected := map[string][3]string{
+			"file0": {"a.log", "text/plain", "first file"},
+			"file1": {"SPOILER_b.txt", "application/octet-stream", "second file"},
+		}
+		for field, expect := range expected {
+			file, header, err := r.FormFile(field)
+			if err != nil {
+				t.Errorf("attempt %d: %s", atomic.LoadInt32(&attempts)+1, err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			contents, _ := ioutil.ReadAll(file)
+			if header.Filename != expect[0] || header.Header.Get("Content-Type") != expect[1] || string(contents) != expect[2] {
+				t.Errorf("%s was uploaded as %s (%s): %q", field, header.Filename, header.Header.Get("Content-Type"), contents)
+			}
+		}
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(`{"id":"42","channel_id":"1"}`))
+	})
+
+	spoiler := &MessageFile{Name: "b.txt", Spoiler: true, Open: func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("second file")), nil
+	}}
+	message, err := session.SendMessageP(1, MessagePrototype{
+		Embed: &Embed{Image: EmbedImage{URL: spoiler.AttachmentURL()}},
+		Files: []*MessageFile{
+			{Name: "a.log", ContentType: "text/plain", Reader: bytes.NewReader([]byte("first file"))},
+			spoiler,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.ID() != 42 || attempts != 2 {
+		t.Fatalf("expected message 42 to be sent after a retry, got %d after %d attempts", message.ID(), attempts)
+	}
+}
+
+func TestSendMessageFileNotRewindable(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := session.SendMessageP(1, MessagePrototype{Files: []*MessageFile{
+		{Name: "a.log", Reader: ioutil.NopCloser(strings.NewReader("can only be read once"))},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "can't be rewound") {
+		t.Fatalf("expected the retry to fail because the file can't be read again, got %v", err)
+	}
+}
+
+func TestSendMessageFileConcurrently(t *testing.T) {
+	var attempts int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Every first attempt is rate limited, so both messages rewind the shared file
+		attempt := atomic.AddInt32(&attempts, 1)
+		if attempt%2 == 1 {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprintf(w, `{"id":"%d","channel_id":"1"}`, attempt)
+	})
+
+	file := &MessageFile{Name: "a.log", Open: func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("shared file")), nil
+	}}
+	before := *file
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := session.SendMessageP(1, MessagePrototype{Files: []*MessageFile{file}}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if file.Name != before.Name || file.Reader != before.Reader {
+		t.Fatalf("sending the file changed it: %+v", file)
+	}
+}
+
+func TestEditChannel(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":"5","guild_id":"1","name":"general","topic":"hello","type":0}`))
+		case "PATCH":
+			body, _ := ioutil.ReadAll(r.Body)
+			if string(body) != `{"name":"lobby","nsfw":true}` {
+				t.Errorf("unexpected body: %s", body)
+			}
+			w.Write([]byte(`{"id":"5","guild_id":"1","name":"lobby","topic":"hello","type":0,"nsfw":true}`))
+		}
+	})
+
+	channel, err := session.GetChannel(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited, err := channel.Edit().Name("lobby").NSFW(true).Apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if edited != channel {
+		t.Fatal("the edited channel is not the cached one")
+	}
+	if channel.Name() != "lobby" || !channel.NSFW() || channel.Topic() != "hello" {
+		t.Fatalf("the cached channel was not updated: %+v", channel.internal)
+	}
+}
+
+func TestPinnedMessages(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`[{"id":"21","channel_id":"1","pinned":true},{"id":"20","channel_id":"1","pinned":true}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	pins, err := session.GetPinnedMessages(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pins) != 2 || !pins[0].Pinned() {
+		t.Fatalf("unexpected pins: %v", pins)
+	}
+
+	if err := pins[1].Unpin(); err != nil {
+		t.Fatal(err)
+	}
+	if pins[1].Pinned() {
+		t.Fatal("the unpinned message is still pinned in the cache")
+	}
+
+	if err := session.PinMessage(1, 20); err != nil {
+		t.Fatal(err)
+	}
+	if !pins[1].Pinned() {
+		t.Fatal("the pinned message is not pinned in the cache")
+	}
+}
@@ -0,0 +1,35 @@
+// Command disgo-restproxy forwards Discord REST calls from any number of processes,
+// so they all share a single view of the rate limits of a bot token.
+//
+// Sessions can be pointed to it using disgo.WithRESTProxy.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/ikkerens/disgo"
+	"github.com/slf4go/logger"
+)
+
+func main() {
+	var token, listen, baseUrl string
+	flag.StringVar(&token, "token", os.Getenv("DISCORD_TOKEN"), "Bot token that is added to all forwarded requests, defaults to $DISCORD_TOKEN")
+	flag.StringVar(&listen, "listen", "127.0.0.1:8080", "Address the proxy listens on")
+	flag.StringVar(&baseUrl, "baseurl", disgo.BaseUrl, "Discord API location requests are forwarded to")
+	flag.Parse()
+
+	if token == "" {
+		logger.Error("No token given, use -token or $DISCORD_TOKEN")
+		os.Exit(2)
+	}
+
+	proxy := disgo.NewRESTProxy(token, disgo.WithBaseUrl(baseUrl))
+
+	logger.Infof("Forwarding REST calls on %s to %s", listen, baseUrl)
+	if err := http.ListenAndServe(listen, proxy); err != nil {
+		logger.ErrorE(err)
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,7 @@
 package disgo
 
-func (e *MessageCreateEvent) Reply(content string) (*Message, error) {
-	return e.session.SendMessage(e.ChannelID(), content)
+func (e *MessageCreateEvent) Reply(content string, options ...RequestOption) (*Message, error) {
+	return e.session.SendMessage(e.ChannelID(), content, options...)
 }
 
 func (e *MessageCreateEvent) Channel() *Channel {
@@ -10,3 +10,8 @@ func (e *MessageCreateEvent) Channel() *Channel {
 
 	return objects.channels[e.internal.ChannelID]
 }
+
+// KeepTyping shows the typing indicator in the channel of the message until the returned function is called, see Session.KeepTyping
+func (e *MessageCreateEvent) KeepTyping(options ...RequestOption) (stop func()) {
+	return e.session.KeepTyping(e.ChannelID(), options...)
+}
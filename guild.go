@@ -1,6 +1,10 @@
 package disgo
 
-import "strconv"
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
 
 func (s *Guild) BuildChannel(name string) *ChannelBuilder {
 	return s.session.BuildChannel(s.internal.ID, name)
@@ -16,12 +20,12 @@ func (s *Guild) Role(id Snowflake) (*Role, bool) {
 	return nil, false
 }
 
-func (s *Session) AddGuildMemberRole(guildID, userID, roleID Snowflake) error {
-	return s.doHttpPut(EndPointGuildMemberRoles(guildID, userID, roleID), nil)
+func (s *Session) AddGuildMemberRole(guildID, userID, roleID Snowflake, options ...RequestOption) error {
+	return s.doHttpPut(EndPointGuildMemberRoles(guildID, userID, roleID), nil, nil, options)
 }
 
-func (s *Session) RemoveGuildMemberRole(guildID, userID, roleID Snowflake) error {
-	return s.doHttpDelete(EndPointGuildMemberRoles(guildID, userID, roleID), nil)
+func (s *Session) RemoveGuildMemberRole(guildID, userID, roleID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointGuildMemberRoles(guildID, userID, roleID), nil, options)
 }
 
 func (s *Guild) GetRoleUsers(roleID Snowflake) []*User {
@@ -77,41 +81,289 @@ func (s *Guild) GetUserColor(userID Snowflake) (int, bool) {
 	return 0, false
 }
 
-type updateGuildMember struct {
-	Nick      string       `json:"nick,omitempty"`
-	Roles     *[]Snowflake `json:"roles,omitempty"`
-	Mute      *bool        `json:"mute,omitempty"`
-	Deaf      *bool        `json:"deaf,omitempty"`
-	ChannelID *Snowflake   `json:"channel_id,omitempty"`
+func (s *Session) SetUserNick(guildID, userID Snowflake, nick string, options ...RequestOption) error {
+	return s.EditGuildMember(guildID, userID).Nick(nick).Apply(options...)
 }
 
-func (s *Session) SetUserNick(guildID, userID Snowflake, nick string) error {
-	return s.doHttpPatch(EndPointGuildMember(guildID, userID), updateGuildMember{Nick: nick}, nil)
+// SetOwnNick changes the nickname of the bot itself, which only requires the permission to change its own nickname
+func (s *Session) SetOwnNick(guildID Snowflake, nick string, options ...RequestOption) error {
+	err := s.doHttpPatch(EndPointGuildOwnNick(guildID), map[string]string{"nick": nick}, nil, options)
+	if err != nil {
+		return err
+	}
+
+	updateCachedGuildMember(guildID, botID, map[string]interface{}{"nick": nick})
+	return nil
+}
+
+func (s *Guild) SetOwnNick(nick string, options ...RequestOption) error {
+	return s.session.SetOwnNick(s.internal.ID, nick, options...)
+}
+
+// EditGuildMember creates a builder for changes to a member of a guild, only the fields that are set on it are sent to Discord
+func (s *Session) EditGuildMember(guildID, userID Snowflake) *GuildMemberEditBuilder {
+	return &GuildMemberEditBuilder{
+		session: s,
+		guildID: guildID,
+		userID:  userID,
+		changes: make(map[string]interface{}),
+	}
+}
+
+func (s *Guild) EditMember(userID Snowflake) *GuildMemberEditBuilder {
+	return s.session.EditGuildMember(s.internal.ID, userID)
+}
+
+func (s *GuildMember) Edit() *GuildMemberEditBuilder {
+	return s.session.EditGuildMember(s.internal.GuildID, s.internal.User.ID())
+}
+
+// updateCachedGuildMember applies the changes of a member edit to the cached member, as Discord doesn't return it
+func updateCachedGuildMember(guildID, userID Snowflake, changes map[string]interface{}) {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	guild.lock.Lock()
+	defer guild.lock.Unlock()
+
+	for _, member := range guild.internal.Members {
+		if member.internal.User.internal.ID != userID {
+			continue
+		}
+
+		if nick, changed := changes["nick"]; changed {
+			member.internal.Nick = nick.(string)
+		}
+		if roles, changed := changes["roles"]; changed {
+			member.internal.RolesIDs = roles.([]Snowflake)
+		}
+		if mute, changed := changes["mute"]; changed {
+			member.internal.Mute = mute.(bool)
+		}
+		if deaf, changed := changes["deaf"]; changed {
+			member.internal.Deaf = deaf.(bool)
+		}
+		return
+	}
 }
 
-func (s *Session) KickUser(guildID, userID Snowflake) error {
-	return s.doHttpDelete(EndPointGuildMember(guildID, userID), nil)
+func (s *Session) KickUser(guildID, userID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointGuildMember(guildID, userID), nil, options)
 }
 
-func (s *Guild) KickUser(userID Snowflake) error {
-	return s.session.KickUser(s.internal.ID, userID)
+func (s *Guild) KickUser(userID Snowflake, options ...RequestOption) error {
+	return s.session.KickUser(s.internal.ID, userID, options...)
 }
 
-func (s *Session) BanUser(guildID, userID Snowflake, deleteMessageDays int) error {
+func (s *Session) BanUser(guildID, userID Snowflake, deleteMessageDays int, options ...RequestOption) error {
 	endPoint := EndPointGuildMemberBan(guildID, userID)
 	endPoint.Url += "?delete-message-days=" + strconv.FormatInt(int64(deleteMessageDays), 10)
 
-	return s.doHttpPut(endPoint, nil)
+	// Bans have a reason of their own, besides the one in the audit log
+	if reason := newRequestConfig(options).reason; reason != "" {
+		endPoint.Url += "&reason=" + url.QueryEscape(reason)
+	}
+
+	return s.doHttpPut(endPoint, nil, nil, options)
+}
+
+func (s *Guild) BanUser(userID Snowflake, deleteMessageDays int, options ...RequestOption) error {
+	return s.session.BanUser(s.internal.ID, userID, deleteMessageDays, options...)
+}
+
+func (s *Session) UnbanUser(guildID, userID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointGuildMemberBan(guildID, userID), nil, options)
+}
+
+func (s *Guild) UnbanUser(userID Snowflake, options ...RequestOption) error {
+	return s.session.UnbanUser(s.internal.ID, userID, options...)
+}
+
+func (s *Session) GetBans(guildID Snowflake, options ...RequestOption) ([]*Ban, error) {
+	bans := make([]*Ban, 0)
+	if err := s.doHttpGet(EndPointGuildBans(guildID), &bans, options); err != nil {
+		return nil, err
+	}
+
+	for _, ban := range bans {
+		ban.session = s
+		if user := ban.internal.User; user != nil && user.session == nil {
+			user.session = s
+		}
+	}
+	return bans, nil
+}
+
+func (s *Guild) GetBans(options ...RequestOption) ([]*Ban, error) {
+	return s.session.GetBans(s.internal.ID, options...)
+}
+
+// BulkResult is the outcome of a bulk operation for a single user, Err is nil if it succeeded
+type BulkResult struct {
+	UserID Snowflake
+	Err    error
+}
+
+// BanUsers bans the users one after the other as fast as the rate limits allow, a failure for one user doesn't stop the others.
+// If the context of the options is done, the remaining users fail with its error.
+func (s *Session) BanUsers(guildID Snowflake, userIDs []Snowflake, deleteMessageDays int, options ...RequestOption) []BulkResult {
+	return bulk(userIDs, func(userID Snowflake) error {
+		return s.BanUser(guildID, userID, deleteMessageDays, options...)
+	})
+}
+
+func (s *Guild) BanUsers(userIDs []Snowflake, deleteMessageDays int, options ...RequestOption) []BulkResult {
+	return s.session.BanUsers(s.internal.ID, userIDs, deleteMessageDays, options...)
 }
 
-func (s *Guild) BanUser(userID Snowflake, deleteMessageDays int) error {
-	return s.session.BanUser(s.internal.ID, userID, deleteMessageDays)
+// UnbanUsers unbans the users one after the other, like BanUsers
+func (s *Session) UnbanUsers(guildID Snowflake, userIDs []Snowflake, options ...RequestOption) []BulkResult {
+	return bulk(userIDs, func(userID Snowflake) error {
+		return s.UnbanUser(guildID, userID, options...)
+	})
 }
 
-func (s *Session) UnbanUser(guildID, userID Snowflake) error {
-	return s.doHttpDelete(EndPointGuildMemberBan(guildID, userID), nil)
+func (s *Guild) UnbanUsers(userIDs []Snowflake, options ...RequestOption) []BulkResult {
+	return s.session.UnbanUsers(s.internal.ID, userIDs, options...)
 }
 
-func (s *Guild) UnbanUser(userID Snowflake) error {
-	return s.session.UnbanUser(s.internal.ID, userID)
+// bulk runs the call for every user, the rate limiter takes care of spacing the requests out
+func bulk(userIDs []Snowflake, call func(userID Snowflake) error) []BulkResult {
+	results := make([]BulkResult, len(userIDs))
+	for i, userID := range userIDs {
+		results[i] = BulkResult{UserID: userID, Err: call(userID)}
+	}
+	return results
+}
+
+const (
+	VerificationLevelNone = iota
+	VerificationLevelLow
+	VerificationLevelMedium
+	VerificationLevelHigh
+	VerificationLevelVeryHigh
+)
+
+const (
+	NotificationsAllMessages = iota
+	NotificationsOnlyMentions
+)
+
+// BuildGuild creates a builder for a new guild, the bot has to be in less than 10 guilds to create one
+func (s *Session) BuildGuild(name string) *GuildBuilder {
+	return &GuildBuilder{
+		session: s,
+
+		Name: name,
+	}
+}
+
+func (s *Session) GetGuild(guildID Snowflake, options ...RequestOption) (*Guild, error) {
+	body := json.RawMessage{}
+	if err := s.doHttpGet(EndPointGuild(guildID), &body, options); err != nil {
+		return nil, err
+	}
+
+	return s.registerGuildResponse(body)
+}
+
+// EditGuild creates a builder for changes to a guild, only the fields that are set on it are sent to Discord
+func (s *Session) EditGuild(guildID Snowflake) *GuildEditBuilder {
+	return &GuildEditBuilder{
+		session: s,
+		guildID: guildID,
+		changes: make(map[string]interface{}),
+	}
+}
+
+func (s *Guild) Edit() *GuildEditBuilder {
+	return s.session.EditGuild(s.internal.ID)
+}
+
+// TransferOwnership makes another member the owner of the guild, only the current owner can do this
+func (s *Session) TransferOwnership(guildID, userID Snowflake, options ...RequestOption) (*Guild, error) {
+	return s.EditGuild(guildID).Owner(userID).Apply(options...)
+}
+
+func (s *Guild) TransferOwnership(userID Snowflake, options ...RequestOption) error {
+	_, err := s.session.TransferOwnership(s.internal.ID, userID, options...)
+	return err
+}
+
+// DeleteGuild deletes a guild permanently, only its owner can do this
+func (s *Session) DeleteGuild(guildID Snowflake, options ...RequestOption) error {
+	if err := s.doHttpDelete(EndPointGuild(guildID), nil, options); err != nil {
+		return err
+	}
+
+	removeCachedGuild(guildID)
+	return nil
+}
+
+func (s *Guild) Delete(options ...RequestOption) error {
+	return s.session.DeleteGuild(s.internal.ID, options...)
+}
+
+// registerGuildResponse registers a guild Discord sent us in a REST response.
+// The roles are decoded on their own first, as decoding into the cached slice would overwrite the roles it points to,
+// and they only replace the cached roles once the whole response was decoded.
+func (s *Session) registerGuildResponse(body json.RawMessage) (*Guild, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+
+	rolesBody, hasRoles := fields["roles"]
+	var roles []*Role
+	if hasRoles {
+		if err := json.Unmarshal(rolesBody, &roles); err != nil {
+			return nil, err
+		}
+		delete(fields, "roles")
+	}
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	guild := &Guild{}
+	if err := json.Unmarshal(body, guild); err != nil {
+		return nil, err
+	}
+
+	guild = objects.registerGuild(guild)
+	if guild.session == nil {
+		guild.session = s
+	}
+	if hasRoles {
+		s.registerGuildRoles(guild.internal.ID, roles)
+	}
+	return guild, nil
+}
+
+// removeCachedGuild removes a guild we're no longer part of from the cache, along with its channels
+func removeCachedGuild(guildID Snowflake) {
+	objects.guildLock.Lock()
+	guild, exists := objects.guilds[guildID]
+	delete(objects.guilds, guildID)
+	objects.guildLock.Unlock()
+
+	if !exists {
+		return
+	}
+
+	// Unmarshalling a guild holds its lock while registering the channels, so the lock of the guild may not be taken after channelLock
+	channels := guild.Channels()
+
+	objects.channelLock.Lock()
+	for _, channel := range channels {
+		delete(objects.channels, channel.ID())
+	}
+	objects.channelLock.Unlock()
 }
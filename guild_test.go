@@ -0,0 +1,146 @@
+package disgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEditGuild(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":"400","name":"Guild","owner_id":"1","roles":[{"id":"401","name":"@everyone"},{"id":"402","name":"mods"}]}`))
+		case "PATCH":
+			// Discord may send the roles in a different order than we have them
+			w.Write([]byte(`{"id":"400","name":"Renamed","owner_id":"2","roles":[{"id":"402","name":"mods"},{"id":"401","name":"@everyone"}]}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	guild, err := session.GetGuild(400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	everyone, _ := guild.Role(401)
+	if guild.Name() != "Guild" || everyone == nil || everyone.GuildID() != 400 {
+		t.Fatalf("unexpected guild: %+v", guild.internal)
+	}
+
+	icon, _ := ImageData("image/png", strings.NewReader("png"))
+	edited, err := guild.Edit().Name("Renamed").Icon(icon).AFKChannel(0).Owner(2).Apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited != guild || guild.Name() != "Renamed" || guild.OwnerID() != 2 {
+		t.Fatalf("the cached guild was not updated: %+v", guild.internal)
+	}
+	if role, _ := guild.Role(401); role != everyone || everyone.Name() != "@everyone" {
+		t.Fatalf("the cached roles got mixed up: %+v", everyone.internal)
+	}
+
+	// A response we can't read must not cost us the roles we have
+	if _, err := session.registerGuildResponse([]byte(`{"id":"400","roles":[{"id":"403","name":"new"}],"owner_id":false}`)); err == nil {
+		t.Fatal("expected the broken response to fail")
+	}
+	if len(guild.Roles()) != 2 {
+		t.Fatalf("the cached roles were lost: %v", guild.Roles())
+	}
+
+	if err := guild.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	objects.guildLock.RLock()
+	_, cached := objects.guilds[400]
+	objects.guildLock.RUnlock()
+	if cached {
+		t.Fatal("the deleted guild is still cached")
+	}
+
+	recorder.expect(t,
+		"GET /guilds/400",
+		`PATCH /guilds/400 {"afk_channel_id":null,"icon":"data:image/png;base64,cG5n","name":"Renamed","owner_id":"2"}`,
+		"DELETE /guilds/400",
+	)
+}
+
+func TestBans(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		switch {
+		case r.Method == "GET":
+			w.Write([]byte(`[{"reason":"raid","user":{"id":"11","username":"raider"}},{"reason":null,"user":{"id":"12","username":"spammer"}}]`))
+		case strings.HasSuffix(r.URL.Path, "/12"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":10026,"message":"Unknown Ban"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	bans, err := session.GetBans(700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bans) != 2 || bans[0].Reason() != "raid" || bans[1].User().Username() != "spammer" {
+		t.Fatalf("unexpected bans: %v", bans)
+	}
+
+	results := session.BanUsers(700, []Snowflake{11, 13}, 1, WithReason("raid"))
+	if len(results) != 2 || results[0].Err != nil || results[1].UserID != 13 || results[1].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	results = session.UnbanUsers(700, []Snowflake{11, 12})
+	if results[0].Err != nil || !IsNotFound(results[1].Err) {
+		t.Fatalf("expected only the second unban to fail, got %+v", results)
+	}
+
+	recorder.expect(t,
+		"GET /guilds/700/bans",
+		"PUT /guilds/700/bans/11?delete-message-days=1&reason=raid",
+		"PUT /guilds/700/bans/13?delete-message-days=1&reason=raid",
+		"DELETE /guilds/700/bans/11",
+		"DELETE /guilds/700/bans/12",
+	)
+}
+
+func TestRemoveCachedGuildWhileUnmarshalling(t *testing.T) {
+	newTestSession(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	// Unmarshalling holds the lock of the guild while registering its channels, removal must not take them the other way around
+	channels := make([]string, 50)
+	for i := range channels {
+		channels[i] = fmt.Sprintf(`{"id":"%d"}`, 1201+i)
+	}
+	body := []byte(fmt.Sprintf(`{"id":"1200","channels":[%s]}`, strings.Join(channels, ",")))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			guild := &Guild{}
+			if err := json.Unmarshal(body, guild); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			removeCachedGuild(1200)
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,146 @@
+package disgo
+
+import (
+	"context"
+	"sort"
+)
+
+type HistoryDirection int
+
+const (
+	// HistoryBackwards walks from the newest message to the oldest one
+	HistoryBackwards HistoryDirection = iota
+	// HistoryForwards walks from the oldest message to the newest one
+	HistoryForwards
+)
+
+// MessageHistoryFilter limits which messages a MessageIterator returns, any zero field is ignored.
+// Start and Stop are exclusive, to use a time as a bound pass it through SnowflakeFromTime.
+type MessageHistoryFilter struct {
+	Direction HistoryDirection
+
+	// Start is the message to start after, by default this is the newest message when walking backwards and the very first one when walking forwards
+	Start Snowflake
+	// Stop is the message the iterator stops at, by default it walks through the full history
+	Stop Snowflake
+
+	// Limit caps the total amount of messages returned
+	Limit int
+	// Filter skips every message it returns false for, skipped messages don't count towards the Limit
+	Filter func(message *Message) bool
+}
+
+// MessageIterator walks through the message history of a channel
+type MessageIterator struct {
+	session   *Session
+	channelID Snowflake
+	filter    MessageHistoryFilter
+	options   []RequestOption
+	ctx       context.Context
+
+	page   []*Message
+	cursor Snowflake
+	pager  pager
+}
+
+// IterateMessages creates an iterator over the message history of a channel.
+// If the request options contain a context, the iterator stops as soon as it is done.
+func (s *Session) IterateMessages(channelID Snowflake, filter MessageHistoryFilter, options ...RequestOption) *MessageIterator {
+	iterator := &MessageIterator{
+		session:   s,
+		channelID: channelID,
+		filter:    filter,
+		options:   options,
+		ctx:       newRequestConfig(options).ctx,
+		cursor:    filter.Start,
+	}
+
+	// With a predicate we can't know how many messages we'll skip, so we always fetch full pages
+	iterator.pager = newPager(100, filter.Limit, iterator.fetch)
+	iterator.pager.minSize = 2
+	iterator.pager.fullPages = filter.Filter != nil
+	return iterator
+}
+
+func (s *Channel) IterateMessages(filter MessageHistoryFilter, options ...RequestOption) *MessageIterator {
+	return s.session.IterateMessages(s.internal.ID, filter, options...)
+}
+
+func (i *MessageIterator) Next() bool {
+	for {
+		if i.pager.done {
+			return false
+		}
+
+		if err := i.ctx.Err(); err != nil {
+			return i.pager.stop(err)
+		}
+
+		if !i.pager.next() {
+			return false
+		}
+
+		message := i.page[i.pager.index]
+		if i.pastStop(message.ID()) {
+			return i.pager.stop(nil)
+		}
+
+		if i.filter.Filter != nil && !i.filter.Filter(message) {
+			i.pager.skip()
+			continue
+		}
+
+		return true
+	}
+}
+
+func (i *MessageIterator) fetch(limit int) (int, error) {
+	var (
+		page []*Message
+		err  error
+	)
+	switch {
+	case i.filter.Direction == HistoryForwards:
+		page, err = i.session.GetMessages(i.channelID, GetMessagesAfter, i.cursor, limit, i.options...)
+	case i.cursor == 0:
+		page, err = i.session.GetLastMessages(i.channelID, limit, i.options...)
+	default:
+		page, err = i.session.GetMessages(i.channelID, GetMessagesBefore, i.cursor, limit, i.options...)
+	}
+	if err != nil || len(page) == 0 {
+		return 0, err
+	}
+
+	// Discord always sends the newest messages first, regardless of which way we're paginating
+	sort.Slice(page, func(a, b int) bool {
+		if i.filter.Direction == HistoryForwards {
+			return page[a].ID() < page[b].ID()
+		}
+		return page[a].ID() > page[b].ID()
+	})
+
+	// Once the iterator reaches a message past the stop it ends, so no page after this one is fetched
+	i.page = page
+	i.cursor = page[len(page)-1].ID()
+	return len(page), nil
+}
+
+func (i *MessageIterator) pastStop(id Snowflake) bool {
+	if i.filter.Stop == 0 {
+		return false
+	}
+
+	if i.filter.Direction == HistoryForwards {
+		return id >= i.filter.Stop
+	}
+	return id <= i.filter.Stop
+}
+
+// Message returns the message the iterator is currently at
+func (i *MessageIterator) Message() *Message {
+	return i.page[i.pager.index]
+}
+
+func (i *MessageIterator) Err() error {
+	return i.pager.err
+}
@@ -0,0 +1,142 @@
+package disgo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newHistoryTestSession serves a channel with 250 messages with IDs 1 to 250
+func newHistoryTestSession(t *testing.T, recorder *requestRecorder) *Session {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		low, high := 1, 250
+		if before := query.Get("before"); before != "" {
+			high, _ = strconv.Atoi(before)
+			if high--; high > 250 {
+				high = 250
+			}
+			low = high - limit + 1
+		} else if after := query.Get("after"); after != "" {
+			low, _ = strconv.Atoi(after)
+			low++
+			high = low + limit - 1
+		} else {
+			low = high - limit + 1
+		}
+
+		// Like Discord, newest first
+		var messages []string
+		for id := high; id >= low; id-- {
+			if id >= 1 && id <= 250 {
+				messages = append(messages, fmt.Sprintf(`{"id": "%d", "channel_id": "1", "content": "%d"}`, id, id))
+			}
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(messages, ","))
+	})
+
+	return session
+}
+
+func TestMessageIteratorBackwards(t *testing.T) {
+	recorder := &requestRecorder{}
+	session := newHistoryTestSession(t, recorder)
+
+	iterator := session.IterateMessages(1, MessageHistoryFilter{})
+	expected := Snowflake(250)
+	for iterator.Next() {
+		if message := iterator.Message(); message.ID() != expected {
+			t.Fatalf("expected message %d, got %d", expected, message.ID())
+		}
+		expected--
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if expected != 0 {
+		t.Fatalf("expected all messages, stopped before %d", expected)
+	}
+	recorder.expect(t,
+		"GET /channels/1/messages?&limit=100",
+		"GET /channels/1/messages?before=151&limit=100",
+		"GET /channels/1/messages?before=51&limit=100",
+	)
+
+	objects.messageLock.RLock()
+	_, cached := objects.messages[123]
+	objects.messageLock.RUnlock()
+	if !cached {
+		t.Fatal("the iterated messages were not cached")
+	}
+}
+
+func TestMessageIteratorForwards(t *testing.T) {
+	recorder := &requestRecorder{}
+	session := newHistoryTestSession(t, recorder)
+
+	iterator := session.IterateMessages(1, MessageHistoryFilter{
+		Direction: HistoryForwards,
+		Start:     10,
+		Stop:      200,
+		Limit:     50,
+		Filter: func(message *Message) bool {
+			return message.ID()%2 == 0
+		},
+	})
+
+	var ids []Snowflake
+	for iterator.Next() {
+		ids = append(ids, iterator.Message().ID())
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 50 || ids[0] != 12 || ids[49] != 110 {
+		t.Fatalf("expected the 50 even messages after 10, got %v", ids)
+	}
+	recorder.expect(t, "GET /channels/1/messages?after=10&limit=100")
+
+	// Stop is exclusive, and nothing past the stop is fetched
+	iterator = session.IterateMessages(1, MessageHistoryFilter{Direction: HistoryForwards, Start: 190, Stop: 200})
+	count := 0
+	for iterator.Next() {
+		count++
+	}
+	if count != 9 {
+		t.Fatalf("expected messages 191 to 199, got %d", count)
+	}
+	recorder.expect(t,
+		"GET /channels/1/messages?after=10&limit=100",
+		"GET /channels/1/messages?after=190&limit=100",
+	)
+}
+
+func TestMessageIteratorContext(t *testing.T) {
+	recorder := &requestRecorder{}
+	session := newHistoryTestSession(t, recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	iterator := session.IterateMessages(1, MessageHistoryFilter{Start: SnowflakeFromTime(time.Now())}, WithContext(ctx))
+	for iterator.Next() {
+		if iterator.Message().ID() == 200 {
+			cancel()
+		}
+	}
+
+	if iterator.Err() != context.Canceled {
+		t.Fatalf("expected the iterator to be canceled, got %v", iterator.Err())
+	}
+	if requests := recorder.Requests(); len(requests) != 1 {
+		t.Fatalf("expected the iterator to stop without fetching another page, got %v", requests)
+	}
+}
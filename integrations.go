@@ -0,0 +1,99 @@
+package disgo
+
+import "sync"
+
+// integrationCache keeps the integrations of the guilds they were fetched for, so they can be refreshed when they change
+type integrationCache struct {
+	lock   sync.RWMutex
+	guilds map[Snowflake][]*Integration
+}
+
+// WithIntegrationCache makes the session remember the integrations it fetched per guild,
+// and fetch them again whenever Discord tells us the integrations of that guild changed.
+func WithIntegrationCache() SessionOption {
+	return func(s *Session) {
+		s.integrations = &integrationCache{guilds: make(map[Snowflake][]*Integration)}
+	}
+}
+
+// GetIntegrations fetches the integrations of a guild
+func (s *Session) GetIntegrations(guildID Snowflake, options ...RequestOption) ([]*Integration, error) {
+	integrations := make([]*Integration, 0)
+	if err := s.doHttpGet(EndPointGuildIntegrations(guildID), &integrations, options); err != nil {
+		return nil, err
+	}
+
+	for _, integration := range integrations {
+		integration.session = s
+		integration.internal.GuildID = guildID
+	}
+
+	if s.integrations != nil {
+		s.integrations.lock.Lock()
+		s.integrations.guilds[guildID] = integrations
+		s.integrations.lock.Unlock()
+	}
+
+	return integrations, nil
+}
+
+func (s *Guild) GetIntegrations(options ...RequestOption) ([]*Integration, error) {
+	return s.session.GetIntegrations(s.internal.ID, options...)
+}
+
+// Integrations returns the cached integrations of a guild, they are only cached if the session was created with WithIntegrationCache
+func (s *Session) Integrations(guildID Snowflake) ([]*Integration, bool) {
+	if s.integrations == nil {
+		return nil, false
+	}
+
+	s.integrations.lock.RLock()
+	defer s.integrations.lock.RUnlock()
+
+	integrations, exists := s.integrations.guilds[guildID]
+	return integrations, exists
+}
+
+// AttachIntegration adds an integration of the given type (like "twitch") from the account of the bot to the guild
+func (s *Session) AttachIntegration(guildID Snowflake, integrationType string, integrationID Snowflake, options ...RequestOption) error {
+	return s.doHttpPost(EndPointGuildIntegrations(guildID), struct {
+		Type string    `json:"type"`
+		ID   Snowflake `json:"id"`
+	}{integrationType, integrationID}, nil, options)
+}
+
+func (s *Guild) AttachIntegration(integrationType string, integrationID Snowflake, options ...RequestOption) error {
+	return s.session.AttachIntegration(s.internal.ID, integrationType, integrationID, options...)
+}
+
+// IntegrationSettings are the settings of an integration that can be modified
+type IntegrationSettings struct {
+	ExpireBehavior IntegrationExpireBehavior `json:"expire_behavior"`
+	// ExpireGracePeriod is the amount of days after which the subscription of a user expires
+	ExpireGracePeriod int  `json:"expire_grace_period"`
+	EnableEmoticons   bool `json:"enable_emoticons"`
+}
+
+func (s *Session) EditIntegration(guildID, integrationID Snowflake, settings IntegrationSettings, options ...RequestOption) error {
+	return s.doHttpPatch(EndPointGuildIntegration(guildID, integrationID), settings, nil, options)
+}
+
+func (s *Integration) Edit(settings IntegrationSettings, options ...RequestOption) error {
+	return s.session.EditIntegration(s.internal.GuildID, s.internal.ID, settings, options...)
+}
+
+func (s *Session) DeleteIntegration(guildID, integrationID Snowflake, options ...RequestOption) error {
+	return s.doHttpDelete(EndPointGuildIntegration(guildID, integrationID), nil, options)
+}
+
+func (s *Integration) Delete(options ...RequestOption) error {
+	return s.session.DeleteIntegration(s.internal.GuildID, s.internal.ID, options...)
+}
+
+func (s *Session) SyncIntegration(guildID, integrationID Snowflake, options ...RequestOption) error {
+	return s.doHttpPost(EndPointGuildIntegrationSync(guildID, integrationID), nil, nil, options)
+}
+
+func (s *Integration) Sync(options ...RequestOption) error {
+	return s.session.SyncIntegration(s.internal.GuildID, s.internal.ID, options...)
+}
@@ -0,0 +1,56 @@
+package disgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIntegrations(t *testing.T) {
+	recorder := &requestRecorder{}
+	name := "Twitch"
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		if r.Method == "GET" {
+			w.Write([]byte(`[{"id":"91","name":"` + name + `","type":"twitch","enabled":true,"expire_behavior":1,"expire_grace_period":7,"account":{"id":"a","name":"streamer"}}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	WithIntegrationCache()(session)
+
+	// Guilds that were never fetched aren't refreshed
+	onGuildIntegrationsUpdate(session, GuildIntegrationsUpdateEvent{GuildID: 900})
+	if len(recorder.Requests()) != 0 {
+		t.Fatal("the integrations of a guild that wasn't cached were refreshed")
+	}
+
+	integrations, err := session.GetIntegrations(900)
+	if err != nil {
+		t.Fatal(err)
+	}
+	integration := integrations[0]
+	if integration.ExpireBehavior() != IntegrationExpireKick || integration.Account().Name != "streamer" || integration.GuildID() != 900 {
+		t.Fatalf("unexpected integration: %+v", integration.internal)
+	}
+
+	if err := integration.Edit(IntegrationSettings{ExpireBehavior: IntegrationExpireRemoveRole, ExpireGracePeriod: 30, EnableEmoticons: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := integration.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	name = "Renamed"
+	onGuildIntegrationsUpdate(session, GuildIntegrationsUpdateEvent{GuildID: 900})
+	if cached, exists := session.Integrations(900); !exists || cached[0].Name() != "Renamed" {
+		t.Fatal("the cached integrations were not refreshed")
+	}
+
+	recorder.expect(t,
+		`GET /guilds/900/integrations`,
+		`PATCH /guilds/900/integrations/91 {"expire_behavior":0,"expire_grace_period":30,"enable_emoticons":true}`,
+		`POST /guilds/900/integrations/91/sync`,
+		`GET /guilds/900/integrations`,
+	)
+}
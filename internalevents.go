@@ -1,5 +1,7 @@
 package disgo
 
+import "github.com/slf4go/logger"
+
 func registerInternalEvents(session *Session) {
 	session.registerEventHandler(onReady, false)
 	session.registerEventHandler(onGuildCreate, false)
@@ -10,6 +12,10 @@ func registerInternalEvents(session *Session) {
 	session.registerEventHandler(onGuildMemberRemove, false)
 	session.registerEventHandler(onMessageReactionAdd, false)
 	session.registerEventHandler(onMessageReactionRemove, false)
+	session.registerEventHandler(onGuildRoleCreate, false)
+	session.registerEventHandler(onGuildRoleDelete, false)
+	// This one makes a REST call, so it can't run on the shard's loop
+	session.registerEventHandler(onGuildIntegrationsUpdate, true)
 }
 
 func onReady(_ *Session, e ReadyEvent) {
@@ -19,6 +25,9 @@ func onReady(_ *Session, e ReadyEvent) {
 		for _, channel := range guild.Channels() {
 			channel.internal.GuildID = guild.internal.ID
 		}
+		for _, role := range guild.Roles() {
+			role.internal.GuildID = guild.internal.ID
+		}
 	}
 }
 
@@ -26,26 +35,25 @@ func onGuildCreate(_ *Session, e GuildCreateEvent) {
 	for _, channel := range e.Channels() {
 		channel.internal.GuildID = e.internal.ID
 	}
+	for _, role := range e.Roles() {
+		role.internal.GuildID = e.internal.ID
+	}
+	for _, member := range e.Members() {
+		member.internal.GuildID = e.internal.ID
+	}
 }
 
-func onGuildMemberAdd(_ *Session, e GuildMemberAddEvent) {
-	objects.guildLock.RLock()
-	guild, exists := objects.guilds[e.GuildID]
-	objects.guildLock.RUnlock()
-
-	if exists {
-		guild.lock.Lock()
-		defer guild.lock.Unlock()
+func onGuildRoleCreate(_ *Session, e GuildRoleCreateEvent) {
+	addCachedGuildRole(e.GuildID, e.Role)
+}
 
-		for i, member := range guild.internal.Members {
-			if member.internal.User.internal.ID == e.GuildMember.internal.User.internal.ID {
-				guild.internal.Members[i] = e.GuildMember
-				return
-			}
-		}
+func onGuildRoleDelete(_ *Session, e GuildRoleDeleteEvent) {
+	removeCachedGuildRole(e.GuildID, e.RoleID)
+}
 
-		guild.internal.Members = append(guild.internal.Members, e.GuildMember)
-	}
+func onGuildMemberAdd(_ *Session, e GuildMemberAddEvent) {
+	e.GuildMember.internal.GuildID = e.GuildID
+	addCachedGuildMembers(e.GuildID, e.GuildMember)
 }
 
 func onGuildMemberRemove(_ *Session, e GuildMemberRemoveEvent) {
@@ -165,3 +173,14 @@ func onMessageReactionRemove(_ *Session, e MessageReactionRemoveEvent) {
 		}
 	}
 }
+
+func onGuildIntegrationsUpdate(s *Session, e GuildIntegrationsUpdateEvent) {
+	// We only refresh the guilds that were fetched before
+	if _, cached := s.Integrations(e.GuildID); !cached {
+		return
+	}
+
+	if _, err := s.GetIntegrations(e.GuildID); err != nil {
+		logger.Errorf("Could not refresh the integrations of guild %s: %s", e.GuildID, err)
+	}
+}
@@ -0,0 +1,91 @@
+package disgo
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// BuildInvite creates a builder for a new invite to a channel, by default it expires after 24 hours and has no limit on its uses
+func (s *Session) BuildInvite(channelID Snowflake) *InviteBuilder {
+	return &InviteBuilder{
+		session:   s,
+		channelID: channelID,
+
+		MaxAge: 86400,
+	}
+}
+
+func (s *Channel) BuildInvite() *InviteBuilder {
+	return s.session.BuildInvite(s.ID())
+}
+
+// GetInvite resolves an invite code to the guild and channel it leads to, withCounts adds the approximate member counts of the guild
+func (s *Session) GetInvite(code string, withCounts bool, options ...RequestOption) (*Invite, error) {
+	endPoint := inviteEndPoint(code)
+	if withCounts {
+		endPoint.Url += "?with_counts=true"
+	}
+
+	invite := &Invite{}
+	if err := s.doHttpGet(endPoint, invite, options); err != nil {
+		return nil, err
+	}
+
+	invite.session = s
+	return invite, nil
+}
+
+// RevokeInvite deletes an invite, it returns the invite that was revoked
+func (s *Session) RevokeInvite(code string, options ...RequestOption) (*Invite, error) {
+	invite := &Invite{}
+	if err := s.doHttpDelete(inviteEndPoint(code), invite, options); err != nil {
+		return nil, err
+	}
+
+	invite.session = s
+	return invite, nil
+}
+
+func (s *Session) GetChannelInvites(channelID Snowflake, options ...RequestOption) ([]*Invite, error) {
+	return s.getInvites(EndPointChannelInvites(channelID), options)
+}
+
+func (s *Session) GetGuildInvites(guildID Snowflake, options ...RequestOption) ([]*Invite, error) {
+	return s.getInvites(EndPointGuildInvites(guildID), options)
+}
+
+func (s *Session) getInvites(endPoint EndPoint, options []RequestOption) ([]*Invite, error) {
+	invites := make([]*Invite, 0)
+	if err := s.doHttpGet(endPoint, &invites, options); err != nil {
+		return nil, err
+	}
+
+	for _, invite := range invites {
+		invite.session = s
+	}
+	return invites, nil
+}
+
+func (s *Channel) GetInvites(options ...RequestOption) ([]*Invite, error) {
+	return s.session.GetChannelInvites(s.ID(), options...)
+}
+
+func (s *Guild) GetInvites(options ...RequestOption) ([]*Invite, error) {
+	return s.session.GetGuildInvites(s.ID(), options...)
+}
+
+// URL returns the link users can use to accept the invite
+func (s *Invite) URL() string {
+	return "https://discord.gg/" + s.internal.Code
+}
+
+func (s *Invite) Revoke(options ...RequestOption) error {
+	_, err := s.session.RevokeInvite(s.internal.Code, options...)
+	return err
+}
+
+func inviteEndPoint(code string) EndPoint {
+	endPoint := EndPointInvite()
+	endPoint.Url = fmt.Sprintf(endPoint.Url, url.PathEscape(code))
+	return endPoint
+}
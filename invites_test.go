@@ -0,0 +1,60 @@
+package disgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestInvites(t *testing.T) {
+	const invite = `{"code":"abc","guild":{"id":"1","name":"Guild"},"channel":{"id":"2","name":"general","type":0},"approximate_member_count":12,"inviter":{"id":"7","username":"mod"},"uses":3,"max_uses":10,"max_age":3600,"temporary":true}`
+
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		if r.URL.Path == "/guilds/1/invites" {
+			w.Write([]byte("[" + invite + "]"))
+			return
+		}
+		w.Write([]byte(invite))
+	})
+
+	builder := session.BuildInvite(2)
+	builder.MaxAge = 3600
+	builder.MaxUses = 10
+	builder.Temporary = true
+	created, err := builder.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Code() != "abc" || created.URL() != "https://discord.gg/abc" || created.Channel().ID != 2 {
+		t.Fatalf("unexpected invite: %+v", created.internal)
+	}
+
+	invites, err := session.GetGuildInvites(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invites) != 1 || invites[0].Uses() != 3 || invites[0].Inviter().Username() != "mod" {
+		t.Fatalf("unexpected invites: %+v", invites)
+	}
+
+	resolved, err := session.GetInvite("abc/../x", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Guild().Name != "Guild" || resolved.ApproximateMemberCount() != 12 {
+		t.Fatalf("unexpected invite: %+v", resolved.internal)
+	}
+
+	if err := invites[0].Revoke(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder.expect(t,
+		`POST /channels/2/invites {"max_age":3600,"max_uses":10,"temporary":true,"unique":false}`,
+		`GET /guilds/1/invites`,
+		`GET /invites/abc%2F..%2Fx?with_counts=true`,
+		`DELETE /invites/abc`,
+	)
+}
@@ -0,0 +1,145 @@
+package disgo
+
+import (
+	"net/url"
+	"strconv"
+)
+
+func (s *Session) GetGuildMember(guildID, userID Snowflake, options ...RequestOption) (*GuildMember, error) {
+	member := &GuildMember{}
+	if err := s.doHttpGet(EndPointGuildMember(guildID, userID), member, options); err != nil {
+		return nil, err
+	}
+
+	s.registerGuildMembers(guildID, []*GuildMember{member})
+	return member, nil
+}
+
+func (s *Guild) GetMember(userID Snowflake, options ...RequestOption) (*GuildMember, error) {
+	return s.session.GetGuildMember(s.internal.ID, userID, options...)
+}
+
+// GuildMemberFilter selects which members are listed, members are sorted by their user ID
+type GuildMemberFilter struct {
+	// After is the user ID to start after, 0 starts at the beginning
+	After Snowflake
+	// Limit caps the amount of members returned, 0 returns all of them when iterating
+	Limit int
+}
+
+// GetGuildMembers fetches a single page of at most 1000 members of a guild, they are merged into the cached members of the guild
+func (s *Session) GetGuildMembers(guildID Snowflake, filter GuildMemberFilter, options ...RequestOption) ([]*GuildMember, error) {
+	query := url.Values{}
+	if filter.After != 0 {
+		query.Set("after", filter.After.String())
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(clampLimit(filter.Limit, 1, 1000)))
+	}
+
+	endPoint := EndPointGuildMembers(guildID)
+	if len(query) != 0 {
+		endPoint.Url += "?" + query.Encode()
+	}
+
+	members := make([]*GuildMember, 0)
+	if err := s.doHttpGet(endPoint, &members, options); err != nil {
+		return nil, err
+	}
+
+	s.registerGuildMembers(guildID, members)
+	return members, nil
+}
+
+func (s *Guild) GetMembers(filter GuildMemberFilter, options ...RequestOption) ([]*GuildMember, error) {
+	return s.session.GetGuildMembers(s.internal.ID, filter, options...)
+}
+
+// GuildMemberIterator walks through all members of a guild
+type GuildMemberIterator struct {
+	session *Session
+	guildID Snowflake
+	filter  GuildMemberFilter
+	options []RequestOption
+
+	page  []*GuildMember
+	pager pager
+}
+
+// ListGuildMembers creates an iterator over the members of a guild, every page is merged into the cached members of the guild
+func (s *Session) ListGuildMembers(guildID Snowflake, filter GuildMemberFilter, options ...RequestOption) *GuildMemberIterator {
+	iterator := &GuildMemberIterator{session: s, guildID: guildID, filter: filter, options: options}
+	iterator.pager = newPager(1000, filter.Limit, iterator.fetch)
+	return iterator
+}
+
+func (s *Guild) ListMembers(filter GuildMemberFilter, options ...RequestOption) *GuildMemberIterator {
+	return s.session.ListGuildMembers(s.internal.ID, filter, options...)
+}
+
+func (i *GuildMemberIterator) Next() bool {
+	return i.pager.next()
+}
+
+func (i *GuildMemberIterator) fetch(limit int) (int, error) {
+	filter := i.filter
+	filter.Limit = limit
+
+	page, err := i.session.GetGuildMembers(i.guildID, filter, i.options...)
+	if err != nil || len(page) == 0 {
+		return 0, err
+	}
+
+	i.page = page
+	i.filter.After = page[len(page)-1].User().ID()
+	return len(page), nil
+}
+
+// Member returns the member the iterator is currently at
+func (i *GuildMemberIterator) Member() *GuildMember {
+	return i.page[i.pager.index]
+}
+
+func (i *GuildMemberIterator) Err() error {
+	return i.pager.err
+}
+
+func (s *Session) registerGuildMembers(guildID Snowflake, members []*GuildMember) {
+	for _, member := range members {
+		member.session = s
+		member.internal.GuildID = guildID
+		if user := member.internal.User; user != nil && user.session == nil {
+			user.session = s
+		}
+	}
+
+	addCachedGuildMembers(guildID, members...)
+}
+
+// addCachedGuildMembers adds members to the cached guild, replacing the members we already knew
+func addCachedGuildMembers(guildID Snowflake, members ...*GuildMember) {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	guild.lock.Lock()
+	defer guild.lock.Unlock()
+
+	known := make(map[Snowflake]int, len(guild.internal.Members))
+	for i, member := range guild.internal.Members {
+		known[member.internal.User.internal.ID] = i
+	}
+
+	for _, member := range members {
+		if i, exists := known[member.internal.User.internal.ID]; exists {
+			guild.internal.Members[i] = member
+		} else {
+			known[member.internal.User.internal.ID] = len(guild.internal.Members)
+			guild.internal.Members = append(guild.internal.Members, member)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package disgo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestListGuildMembers(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		if r.URL.Path == "/guilds/500/members/7" {
+			w.Write([]byte(`{"user":{"id":"7","username":"user7"},"nick":"seven","roles":["501"]}`))
+			return
+		}
+
+		// 2500 members with user IDs 1 to 2500
+		after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var members []string
+		for id := after + 1; id <= 2500 && len(members) < limit; id++ {
+			members = append(members, fmt.Sprintf(`{"user":{"id":"%d","username":"user%d"},"roles":["501"]}`, id, id))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(members, ","))
+	})
+
+	guild := newTestGuild(session, 500)
+	guild.internal.Roles = []*Role{objects.registerRole(&Role{internal: &internalRole{ID: 501, Color: 0xff0000}, lock: new(sync.RWMutex)})}
+	guild.internal.Members = []*GuildMember{{internal: &internalGuildMember{User: objects.registerUser(&User{internal: &internalUser{ID: 7}, lock: new(sync.RWMutex)})}}}
+
+	iterator := guild.ListMembers(GuildMemberFilter{})
+	count := 0
+	for iterator.Next() {
+		count++
+		if member := iterator.Member(); member.User().ID() != Snowflake(count) || member.GuildID() != 500 {
+			t.Fatalf("unexpected member %d: %+v", count, member.internal)
+		}
+	}
+
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2500 {
+		t.Fatalf("expected 2500 members, got %d", count)
+	}
+	recorder.expect(t,
+		"GET /guilds/500/members?limit=1000",
+		"GET /guilds/500/members?after=1000&limit=1000",
+		"GET /guilds/500/members?after=2000&limit=1000",
+	)
+	if len(guild.Members()) != 2500 {
+		t.Fatalf("expected the members to be merged into the guild, it has %d", len(guild.Members()))
+	}
+	if color, exists := guild.GetUserColor(2345); !exists || color != 0xff0000 {
+		t.Fatal("the color of a fetched member is unknown")
+	}
+
+	member, err := guild.GetMember(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if membership, _ := guild.GetUserMembership(7); membership != member || membership.Nick() != "seven" || len(guild.Members()) != 2500 {
+		t.Fatal("the fetched member did not replace the cached one")
+	}
+}
+
+func TestEditGuildMember(t *testing.T) {
+	defer func(id Snowflake) { botID = id }(botID)
+	botID = 2
+
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	guild := newTestGuild(session, 600)
+	for _, id := range []Snowflake{1, 2} {
+		user := objects.registerUser(&User{internal: &internalUser{ID: id}, lock: new(sync.RWMutex)})
+		guild.internal.Members = append(guild.internal.Members, &GuildMember{session: session, internal: &internalGuildMember{User: user, GuildID: 600}})
+	}
+	member, _ := guild.GetUserMembership(1)
+
+	if err := member.Edit().Roles([]Snowflake{601, 602}).Mute(true).Move(603).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if len(member.RolesIDs()) != 2 || !member.Mute() {
+		t.Fatalf("the cached member was not updated: %+v", member.internal)
+	}
+
+	if err := guild.EditMember(1).Disconnect().Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if err := guild.EditMember(1).Move(0).Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := guild.EditMember(2).Nick("bot").Deaf(true).Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if self, _ := guild.GetUserMembership(2); self.Nick() != "bot" || !self.Deaf() {
+		t.Fatalf("the cached member was not updated: %+v", self.internal)
+	}
+
+	recorder.expect(t,
+		`PATCH /guilds/600/members/1 {"channel_id":"603","mute":true,"roles":["601","602"]}`,
+		`PATCH /guilds/600/members/1 {"channel_id":null}`,
+		`PATCH /guilds/600/members/1 {"channel_id":null}`,
+		`PATCH /guilds/600/members/@me/nick {"nick":"bot"}`,
+		`PATCH /guilds/600/members/2 {"deaf":true}`,
+	)
+}
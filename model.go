@@ -20,11 +20,21 @@ func ParseSnowflake(str string) (Snowflake, error) {
 	return Snowflake(intVal), err
 }
 
+const discordEpoch int64 = 1420070400000
+
 func (s Snowflake) Timestamp() time.Time {
-	const discordEpoch int64 = 1420070400000
 	return time.Unix(((int64(s)>>22)+discordEpoch)/1000, 0)
 }
 
+// SnowflakeFromTime returns the lowest snowflake that could have been created at the given time, useful as a bound for pagination
+func SnowflakeFromTime(t time.Time) Snowflake {
+	milliseconds := t.UnixNano()/int64(time.Millisecond) - discordEpoch
+	if milliseconds < 0 {
+		return 0
+	}
+	return Snowflake(milliseconds << 22)
+}
+
 func (s Snowflake) String() string {
 	return strconv.FormatUint(uint64(s), 10)
 }
@@ -132,6 +142,79 @@ const (
 	PermissionManageEmojis        = 0x40000000
 )
 
+/***********************/
+/* Resources/Audit Log */
+/***********************/
+
+type AuditLogAction int
+
+const (
+	AuditLogActionGuildUpdate            AuditLogAction = 1
+	AuditLogActionChannelCreate          AuditLogAction = 10
+	AuditLogActionChannelUpdate          AuditLogAction = 11
+	AuditLogActionChannelDelete          AuditLogAction = 12
+	AuditLogActionChannelOverwriteCreate AuditLogAction = 13
+	AuditLogActionChannelOverwriteUpdate AuditLogAction = 14
+	AuditLogActionChannelOverwriteDelete AuditLogAction = 15
+	AuditLogActionMemberKick             AuditLogAction = 20
+	AuditLogActionMemberPrune            AuditLogAction = 21
+	AuditLogActionMemberBanAdd           AuditLogAction = 22
+	AuditLogActionMemberBanRemove        AuditLogAction = 23
+	AuditLogActionMemberUpdate           AuditLogAction = 24
+	AuditLogActionMemberRoleUpdate       AuditLogAction = 25
+	AuditLogActionRoleCreate             AuditLogAction = 30
+	AuditLogActionRoleUpdate             AuditLogAction = 31
+	AuditLogActionRoleDelete             AuditLogAction = 32
+	AuditLogActionInviteCreate           AuditLogAction = 40
+	AuditLogActionInviteUpdate           AuditLogAction = 41
+	AuditLogActionInviteDelete           AuditLogAction = 42
+	AuditLogActionWebhookCreate          AuditLogAction = 50
+	AuditLogActionWebhookUpdate          AuditLogAction = 51
+	AuditLogActionWebhookDelete          AuditLogAction = 52
+	AuditLogActionEmojiCreate            AuditLogAction = 60
+	AuditLogActionEmojiUpdate            AuditLogAction = 61
+	AuditLogActionEmojiDelete            AuditLogAction = 62
+	AuditLogActionMessageDelete          AuditLogAction = 72
+)
+
+type internalAuditLogEntry struct {
+	ID         Snowflake             `json:"id"`
+	TargetID   Snowflake             `json:"target_id"`
+	UserID     Snowflake             `json:"user_id"`
+	ActionType AuditLogAction        `json:"action_type"`
+	Changes    []AuditLogChange      `json:"changes"`
+	Options    *AuditLogEntryOptions `json:"options"`
+	Reason     string                `json:"reason"`
+}
+
+// AuditLogChange holds the old and new value of a single key of the changed object, their type depends on the key
+type AuditLogChange struct {
+	Key      string          `json:"key"`
+	OldValue json.RawMessage `json:"old_value,omitempty"`
+	NewValue json.RawMessage `json:"new_value,omitempty"`
+}
+
+// DecodeOld decodes the old value of the change into target, which should be of the type matching the key
+func (c *AuditLogChange) DecodeOld(target interface{}) error {
+	return json.Unmarshal(c.OldValue, target)
+}
+
+// DecodeNew decodes the new value of the change into target, which should be of the type matching the key
+func (c *AuditLogChange) DecodeNew(target interface{}) error {
+	return json.Unmarshal(c.NewValue, target)
+}
+
+// AuditLogEntryOptions holds extra information for some of the action types
+type AuditLogEntryOptions struct {
+	DeleteMemberDays string    `json:"delete_member_days,omitempty"`
+	MembersRemoved   string    `json:"members_removed,omitempty"`
+	ChannelID        Snowflake `json:"channel_id,omitempty"`
+	Count            string    `json:"count,omitempty"`
+	ID               Snowflake `json:"id,omitempty"`
+	Type             string    `json:"type,omitempty"`
+	RoleName         string    `json:"role_name,omitempty"`
+}
+
 /*********************/
 /* Resources/Channel */
 /*********************/
@@ -177,6 +260,7 @@ type internalChannel struct {
 	LastMessageID        Snowflake   `json:"last_message_id,omitempty"`
 	Bitrate              int         `json:"bitrate"`
 	UserLimit            int         `json:"user_limit"`
+	NSFW                 bool        `json:"nsfw"`
 
 	// DMChannel
 	Recipients []*User `json:"recipients"`
@@ -336,6 +420,54 @@ type internalGuildMember struct {
 	JoinedAt DiscordTime `json:"joined_at"`
 	Deaf     bool        `json:"deaf"`
 	Mute     bool        `json:"mute"`
+
+	// Filled in from the guild when Discord leaves it out
+	GuildID Snowflake `json:"-"`
+}
+
+// internalUserGuild is the partial guild Discord lists for the guilds the current user is in
+type internalUserGuild struct {
+	ID          Snowflake `json:"id"`
+	Name        string    `json:"name"`
+	IconHash    string    `json:"icon"`
+	Owner       bool      `json:"owner"`
+	Permissions int       `json:"permissions"`
+}
+
+type internalBan struct {
+	Reason string `json:"reason"`
+	User   *User  `json:"user"`
+}
+
+type IntegrationExpireBehavior int
+
+const (
+	IntegrationExpireRemoveRole IntegrationExpireBehavior = iota
+	IntegrationExpireKick
+)
+
+type internalIntegration struct {
+	ID                Snowflake                 `json:"id"`
+	Name              string                    `json:"name"`
+	Type              string                    `json:"type"`
+	Enabled           bool                      `json:"enabled"`
+	Syncing           bool                      `json:"syncing"`
+	RoleID            Snowflake                 `json:"role_id"`
+	EnableEmoticons   bool                      `json:"enable_emoticons"`
+	ExpireBehavior    IntegrationExpireBehavior `json:"expire_behavior"`
+	ExpireGracePeriod int                       `json:"expire_grace_period"`
+	User              *User                     `json:"user"`
+	Account           IntegrationAccount        `json:"account"`
+	SyncedAt          DiscordTime               `json:"synced_at"`
+
+	// Filled in from the guild the integrations were fetched for
+	GuildID Snowflake `json:"-"`
+}
+
+// IntegrationAccount is the account on the service (like Twitch or YouTube) an integration belongs to
+type IntegrationAccount struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type internalEmoji struct {
@@ -346,6 +478,44 @@ type internalEmoji struct {
 	Managed       bool        `json:"managed"`
 }
 
+/********************/
+/* Resources/Invite */
+/********************/
+
+type internalInvite struct {
+	Code    string        `json:"code"`
+	Guild   InviteGuild   `json:"guild"`
+	Channel InviteChannel `json:"channel"`
+
+	// Only sent when the invite is resolved with counts
+	ApproximatePresenceCount int `json:"approximate_presence_count"`
+	ApproximateMemberCount   int `json:"approximate_member_count"`
+
+	// Metadata, only sent to users that are allowed to manage the invites
+	Inviter   *User       `json:"inviter"`
+	Uses      int         `json:"uses"`
+	MaxUses   int         `json:"max_uses"`
+	MaxAge    int         `json:"max_age"`
+	Temporary bool        `json:"temporary"`
+	CreatedAt DiscordTime `json:"created_at"`
+	Revoked   bool        `json:"revoked"`
+}
+
+// InviteGuild is the partial guild sent along with an invite, we might not be a member of it
+type InviteGuild struct {
+	ID         Snowflake `json:"id"`
+	Name       string    `json:"name"`
+	IconHash   string    `json:"icon"`
+	SplashHash string    `json:"splash"`
+}
+
+// InviteChannel is the partial channel sent along with an invite
+type InviteChannel struct {
+	ID   Snowflake   `json:"id"`
+	Name string      `json:"name"`
+	Type ChannelType `json:"type"`
+}
+
 /******************/
 /* Resources/User */
 /******************/
@@ -370,6 +540,9 @@ type internalRole struct {
 	Permissions int       `json:"permissions"`
 	Managed     bool      `json:"managed"`
 	Mentionable bool      `json:"mentionable"`
+
+	// Filled in from the guild the role belongs to
+	GuildID Snowflake `json:"-"`
 }
 
 type internalPresence struct {
@@ -385,3 +558,30 @@ type internalGame struct {
 	Type int    `json:"type"`
 	URL  string `json:"url,omitempty"`
 }
+
+/*******************/
+/* Resources/Voice */
+/*******************/
+
+type internalVoiceRegion struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	VIP        bool   `json:"vip"`
+	Optimal    bool   `json:"optimal"`
+	Deprecated bool   `json:"deprecated"`
+	Custom     bool   `json:"custom"`
+}
+
+/*********************/
+/* Resources/Webhook */
+/*********************/
+
+type internalWebhook struct {
+	ID         Snowflake `json:"id"`
+	GuildID    Snowflake `json:"guild_id"`
+	ChannelID  Snowflake `json:"channel_id"`
+	User       *User     `json:"user"`
+	Name       string    `json:"name"`
+	AvatarHash string    `json:"avatar"`
+	Token      string    `json:"token"`
+}
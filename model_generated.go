@@ -61,6 +61,87 @@ func (s *Attachment) Width() int {
 	return s.internal.Width
 }
 
+// AuditLogEntry is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type AuditLogEntry struct {
+	session  *Session
+	internal *internalAuditLogEntry
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *AuditLogEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *AuditLogEntry) UnmarshalJSON(b []byte) error {
+	s.internal = &internalAuditLogEntry{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// ID is used to export the ID from this struct.
+func (s *AuditLogEntry) ID() Snowflake {
+	return s.internal.ID
+}
+
+// TargetID is used to export the TargetID from this struct.
+func (s *AuditLogEntry) TargetID() Snowflake {
+	return s.internal.TargetID
+}
+
+// UserID is used to export the UserID from this struct.
+func (s *AuditLogEntry) UserID() Snowflake {
+	return s.internal.UserID
+}
+
+// ActionType is used to export the ActionType from this struct.
+func (s *AuditLogEntry) ActionType() AuditLogAction {
+	return s.internal.ActionType
+}
+
+// Changes is used to export the Changes from this struct.
+func (s *AuditLogEntry) Changes() []AuditLogChange {
+	return s.internal.Changes
+}
+
+// Options is used to export the Options from this struct.
+func (s *AuditLogEntry) Options() *AuditLogEntryOptions {
+	return s.internal.Options
+}
+
+// Reason is used to export the Reason from this struct.
+func (s *AuditLogEntry) Reason() string {
+	return s.internal.Reason
+}
+
+// Ban is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type Ban struct {
+	session  *Session
+	internal *internalBan
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *Ban) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *Ban) UnmarshalJSON(b []byte) error {
+	s.internal = &internalBan{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// Reason is used to export the Reason from this struct.
+func (s *Ban) Reason() string {
+	return s.internal.Reason
+}
+
+// User is used to export the User from this struct.
+func (s *Ban) User() *User {
+	return s.internal.User
+}
+
 // Channel is based on the Discord object with the same name.
 // Any fields can be obtained by calling the respective getters.
 type Channel struct {
@@ -183,6 +264,14 @@ func (s *Channel) UserLimit() int {
 	return s.internal.UserLimit
 }
 
+// NSFW is used to export the NSFW from this struct.
+func (s *Channel) NSFW() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.internal.NSFW
+}
+
 // Recipients is used to export the Recipients from this struct.
 func (s *Channel) Recipients() []*User {
 	s.lock.RLock()
@@ -554,6 +643,172 @@ func (s *GuildMember) Mute() bool {
 	return s.internal.Mute
 }
 
+// GuildID is used to export the GuildID from this struct.
+func (s *GuildMember) GuildID() Snowflake {
+	return s.internal.GuildID
+}
+
+// Integration is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type Integration struct {
+	session  *Session
+	internal *internalIntegration
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *Integration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *Integration) UnmarshalJSON(b []byte) error {
+	s.internal = &internalIntegration{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// ID is used to export the ID from this struct.
+func (s *Integration) ID() Snowflake {
+	return s.internal.ID
+}
+
+// Name is used to export the Name from this struct.
+func (s *Integration) Name() string {
+	return s.internal.Name
+}
+
+// Type is used to export the Type from this struct.
+func (s *Integration) Type() string {
+	return s.internal.Type
+}
+
+// Enabled is used to export the Enabled from this struct.
+func (s *Integration) Enabled() bool {
+	return s.internal.Enabled
+}
+
+// Syncing is used to export the Syncing from this struct.
+func (s *Integration) Syncing() bool {
+	return s.internal.Syncing
+}
+
+// RoleID is used to export the RoleID from this struct.
+func (s *Integration) RoleID() Snowflake {
+	return s.internal.RoleID
+}
+
+// EnableEmoticons is used to export the EnableEmoticons from this struct.
+func (s *Integration) EnableEmoticons() bool {
+	return s.internal.EnableEmoticons
+}
+
+// ExpireBehavior is used to export the ExpireBehavior from this struct.
+func (s *Integration) ExpireBehavior() IntegrationExpireBehavior {
+	return s.internal.ExpireBehavior
+}
+
+// ExpireGracePeriod is used to export the ExpireGracePeriod from this struct.
+func (s *Integration) ExpireGracePeriod() int {
+	return s.internal.ExpireGracePeriod
+}
+
+// User is used to export the User from this struct.
+func (s *Integration) User() *User {
+	return s.internal.User
+}
+
+// Account is used to export the Account from this struct.
+func (s *Integration) Account() IntegrationAccount {
+	return s.internal.Account
+}
+
+// SyncedAt is used to export the SyncedAt from this struct.
+func (s *Integration) SyncedAt() DiscordTime {
+	return s.internal.SyncedAt
+}
+
+// GuildID is used to export the GuildID from this struct.
+func (s *Integration) GuildID() Snowflake {
+	return s.internal.GuildID
+}
+
+// Invite is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type Invite struct {
+	session  *Session
+	internal *internalInvite
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *Invite) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *Invite) UnmarshalJSON(b []byte) error {
+	s.internal = &internalInvite{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// Code is used to export the Code from this struct.
+func (s *Invite) Code() string {
+	return s.internal.Code
+}
+
+// Guild is used to export the Guild from this struct.
+func (s *Invite) Guild() InviteGuild {
+	return s.internal.Guild
+}
+
+// Channel is used to export the Channel from this struct.
+func (s *Invite) Channel() InviteChannel {
+	return s.internal.Channel
+}
+
+// ApproximatePresenceCount is used to export the ApproximatePresenceCount from this struct.
+func (s *Invite) ApproximatePresenceCount() int {
+	return s.internal.ApproximatePresenceCount
+}
+
+// ApproximateMemberCount is used to export the ApproximateMemberCount from this struct.
+func (s *Invite) ApproximateMemberCount() int {
+	return s.internal.ApproximateMemberCount
+}
+
+// Inviter is used to export the Inviter from this struct.
+func (s *Invite) Inviter() *User {
+	return s.internal.Inviter
+}
+
+// Uses is used to export the Uses from this struct.
+func (s *Invite) Uses() int {
+	return s.internal.Uses
+}
+
+// MaxUses is used to export the MaxUses from this struct.
+func (s *Invite) MaxUses() int {
+	return s.internal.MaxUses
+}
+
+// MaxAge is used to export the MaxAge from this struct.
+func (s *Invite) MaxAge() int {
+	return s.internal.MaxAge
+}
+
+// Temporary is used to export the Temporary from this struct.
+func (s *Invite) Temporary() bool {
+	return s.internal.Temporary
+}
+
+// CreatedAt is used to export the CreatedAt from this struct.
+func (s *Invite) CreatedAt() DiscordTime {
+	return s.internal.CreatedAt
+}
+
+// Revoked is used to export the Revoked from this struct.
+func (s *Invite) Revoked() bool {
+	return s.internal.Revoked
+}
+
 // Message is based on the Discord object with the same name.
 // Any fields can be obtained by calling the respective getters.
 type Message struct {
@@ -912,6 +1167,14 @@ func (s *Role) Mentionable() bool {
 	return s.internal.Mentionable
 }
 
+// GuildID is used to export the GuildID from this struct.
+func (s *Role) GuildID() Snowflake {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.internal.GuildID
+}
+
 // User is based on the Discord object with the same name.
 // Any fields can be obtained by calling the respective getters.
 type User struct {
@@ -1012,3 +1275,147 @@ func (s *User) EMail() string {
 
 	return s.internal.EMail
 }
+
+// UserGuild is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type UserGuild struct {
+	session  *Session
+	internal *internalUserGuild
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *UserGuild) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *UserGuild) UnmarshalJSON(b []byte) error {
+	s.internal = &internalUserGuild{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// ID is used to export the ID from this struct.
+func (s *UserGuild) ID() Snowflake {
+	return s.internal.ID
+}
+
+// Name is used to export the Name from this struct.
+func (s *UserGuild) Name() string {
+	return s.internal.Name
+}
+
+// IconHash is used to export the IconHash from this struct.
+func (s *UserGuild) IconHash() string {
+	return s.internal.IconHash
+}
+
+// Owner is used to export the Owner from this struct.
+func (s *UserGuild) Owner() bool {
+	return s.internal.Owner
+}
+
+// Permissions is used to export the Permissions from this struct.
+func (s *UserGuild) Permissions() int {
+	return s.internal.Permissions
+}
+
+// VoiceRegion is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type VoiceRegion struct {
+	session  *Session
+	internal *internalVoiceRegion
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *VoiceRegion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *VoiceRegion) UnmarshalJSON(b []byte) error {
+	s.internal = &internalVoiceRegion{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// ID is used to export the ID from this struct.
+func (s *VoiceRegion) ID() string {
+	return s.internal.ID
+}
+
+// Name is used to export the Name from this struct.
+func (s *VoiceRegion) Name() string {
+	return s.internal.Name
+}
+
+// VIP is used to export the VIP from this struct.
+func (s *VoiceRegion) VIP() bool {
+	return s.internal.VIP
+}
+
+// Optimal is used to export the Optimal from this struct.
+func (s *VoiceRegion) Optimal() bool {
+	return s.internal.Optimal
+}
+
+// Deprecated is used to export the Deprecated from this struct.
+func (s *VoiceRegion) Deprecated() bool {
+	return s.internal.Deprecated
+}
+
+// Custom is used to export the Custom from this struct.
+func (s *VoiceRegion) Custom() bool {
+	return s.internal.Custom
+}
+
+// Webhook is based on the Discord object with the same name.
+// Any fields can be obtained by calling the respective getters.
+type Webhook struct {
+	session  *Session
+	internal *internalWebhook
+}
+
+// MarshalJSON is used to convert this object into its json representation for Discord
+func (s *Webhook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.internal)
+}
+
+// UnmarshalJSON is used to convert json discord objects back into their respective structs
+func (s *Webhook) UnmarshalJSON(b []byte) error {
+	s.internal = &internalWebhook{}
+	return json.Unmarshal(b, &s.internal)
+}
+
+// ID is used to export the ID from this struct.
+func (s *Webhook) ID() Snowflake {
+	return s.internal.ID
+}
+
+// GuildID is used to export the GuildID from this struct.
+func (s *Webhook) GuildID() Snowflake {
+	return s.internal.GuildID
+}
+
+// ChannelID is used to export the ChannelID from this struct.
+func (s *Webhook) ChannelID() Snowflake {
+	return s.internal.ChannelID
+}
+
+// User is used to export the User from this struct.
+func (s *Webhook) User() *User {
+	return s.internal.User
+}
+
+// Name is used to export the Name from this struct.
+func (s *Webhook) Name() string {
+	return s.internal.Name
+}
+
+// AvatarHash is used to export the AvatarHash from this struct.
+func (s *Webhook) AvatarHash() string {
+	return s.internal.AvatarHash
+}
+
+// Token is used to export the Token from this struct.
+func (s *Webhook) Token() string {
+	return s.internal.Token
+}
@@ -0,0 +1,125 @@
+package disgo
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// ErrOwnGuildDirection is returned for an OwnGuildFilter that sets both Before and After, guilds are listed in only one direction
+var ErrOwnGuildDirection = errors.New("OwnGuildFilter can't have both Before and After set")
+
+// OwnGuildFilter selects which of our guilds are listed, guilds are sorted by their ID
+type OwnGuildFilter struct {
+	// Before makes the iterator walk backwards from the guild with this ID, After walks forwards from it, only one of them may be set
+	Before Snowflake
+	After  Snowflake
+	// Limit caps the amount of guilds returned, 0 returns all of them
+	Limit int
+}
+
+// GetOwnGuildsPage fetches a single page of at most 100 of the guilds we are in
+func (s *Session) GetOwnGuildsPage(filter OwnGuildFilter, options ...RequestOption) ([]*UserGuild, error) {
+	if filter.Before != 0 && filter.After != 0 {
+		return nil, ErrOwnGuildDirection
+	}
+
+	query := url.Values{}
+	if filter.Before != 0 {
+		query.Set("before", filter.Before.String())
+	}
+	if filter.After != 0 {
+		query.Set("after", filter.After.String())
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(clampLimit(filter.Limit, 1, 100)))
+	}
+
+	endPoint := EndPointOwnGuilds()
+	if len(query) != 0 {
+		endPoint.Url += "?" + query.Encode()
+	}
+
+	guilds := make([]*UserGuild, 0)
+	if err := s.doHttpGet(endPoint, &guilds, options); err != nil {
+		return nil, err
+	}
+
+	for _, guild := range guilds {
+		guild.session = s
+	}
+	return guilds, nil
+}
+
+// OwnGuildIterator walks through the guilds we are in, over REST so it can be used before the gateway connection is up
+type OwnGuildIterator struct {
+	session *Session
+	filter  OwnGuildFilter
+	options []RequestOption
+
+	page  []*UserGuild
+	pager pager
+}
+
+// GetOwnGuilds creates an iterator over the guilds we are in
+func (s *Session) GetOwnGuilds(filter OwnGuildFilter, options ...RequestOption) *OwnGuildIterator {
+	iterator := &OwnGuildIterator{session: s, filter: filter, options: options}
+	iterator.pager = newPager(100, filter.Limit, iterator.fetch)
+	return iterator
+}
+
+func (i *OwnGuildIterator) Next() bool {
+	return i.pager.next()
+}
+
+func (i *OwnGuildIterator) fetch(limit int) (int, error) {
+	filter := i.filter
+	filter.Limit = limit
+
+	page, err := i.session.GetOwnGuildsPage(filter, i.options...)
+	if err != nil || len(page) == 0 {
+		return 0, err
+	}
+
+	// Discord sends the oldest guilds first, so walking backwards means reading every page in reverse
+	if i.filter.Before != 0 {
+		for a, b := 0, len(page)-1; a < b; a, b = a+1, b-1 {
+			page[a], page[b] = page[b], page[a]
+		}
+	}
+
+	i.page = page
+	if i.filter.Before != 0 {
+		i.filter.Before = page[len(page)-1].ID()
+	} else {
+		i.filter.After = page[len(page)-1].ID()
+	}
+	return len(page), nil
+}
+
+// Guild returns the guild the iterator is currently at
+func (i *OwnGuildIterator) Guild() *UserGuild {
+	return i.page[i.pager.index]
+}
+
+func (i *OwnGuildIterator) Err() error {
+	return i.pager.err
+}
+
+// LeaveGuild makes us leave a guild, the guild and its channels are removed from the cache
+func (s *Session) LeaveGuild(guildID Snowflake, options ...RequestOption) error {
+	if err := s.doHttpDelete(EndPointOwnGuild(guildID), nil, options); err != nil {
+		return err
+	}
+
+	removeCachedGuild(guildID)
+	return nil
+}
+
+func (s *Guild) Leave(options ...RequestOption) error {
+	return s.session.LeaveGuild(s.internal.ID, options...)
+}
+
+func (s *UserGuild) Leave(options ...RequestOption) error {
+	return s.session.LeaveGuild(s.internal.ID, options...)
+}
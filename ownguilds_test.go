@@ -0,0 +1,109 @@
+package disgo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOwnGuildIterator(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		// 250 guilds with IDs 1 to 250, oldest first like Discord
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		low, high := 1, 250
+		if after := query.Get("after"); after != "" {
+			low, _ = strconv.Atoi(after)
+			low++
+		}
+		if before := query.Get("before"); before != "" {
+			high, _ = strconv.Atoi(before)
+			high--
+			if high-limit+1 > low {
+				low = high - limit + 1
+			}
+		}
+
+		var guilds []string
+		for id := low; id <= high && len(guilds) < limit; id++ {
+			guilds = append(guilds, fmt.Sprintf(`{"id":"%d","name":"Guild %d"}`, id, id))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(guilds, ","))
+	})
+
+	iterator := session.GetOwnGuilds(OwnGuildFilter{})
+	count := 0
+	for iterator.Next() {
+		count++
+		if guild := iterator.Guild(); guild.ID() != Snowflake(count) {
+			t.Fatalf("expected guild %d, got %d", count, guild.ID())
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 250 {
+		t.Fatalf("expected 250 guilds, got %d", count)
+	}
+	recorder.expect(t,
+		"GET /users/@me/guilds?limit=100",
+		"GET /users/@me/guilds?after=100&limit=100",
+		"GET /users/@me/guilds?after=200&limit=100",
+	)
+
+	recorder = &requestRecorder{}
+	iterator = session.GetOwnGuilds(OwnGuildFilter{Before: 201, Limit: 150})
+	expected := Snowflake(200)
+	for iterator.Next() {
+		if guild := iterator.Guild(); guild.ID() != expected {
+			t.Fatalf("expected guild %d, got %d", expected, guild.ID())
+		}
+		expected--
+	}
+	if expected != 50 {
+		t.Fatalf("expected guilds 200 to 51, stopped at %d", expected)
+	}
+	recorder.expect(t,
+		"GET /users/@me/guilds?before=201&limit=100",
+		"GET /users/@me/guilds?before=101&limit=50",
+	)
+
+	recorder = &requestRecorder{}
+	iterator = session.GetOwnGuilds(OwnGuildFilter{Before: 201, After: 100})
+	if iterator.Next() || iterator.Err() != ErrOwnGuildDirection || len(recorder.Requests()) != 0 {
+		t.Fatalf("expected a filter with both Before and After to be rejected, got %v", iterator.Err())
+	}
+}
+
+func TestLeaveGuild(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/users/@me/guilds/1100" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	channel := objects.registerChannel(&Channel{internal: &internalChannel{ID: 1101, GuildID: 1100}, lock: new(sync.RWMutex)})
+	guild := newTestGuild(session, 1100)
+	guild.internal.Channels = []*Channel{channel}
+
+	if err := guild.Leave(); err != nil {
+		t.Fatal(err)
+	}
+
+	objects.guildLock.RLock()
+	_, guildCached := objects.guilds[1100]
+	objects.guildLock.RUnlock()
+	objects.channelLock.RLock()
+	_, channelCached := objects.channels[1101]
+	objects.channelLock.RUnlock()
+	if guildCached || channelCached {
+		t.Fatal("the guild we left is still cached")
+	}
+}
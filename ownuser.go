@@ -15,24 +15,33 @@ type modifyCurrentUser struct {
 	Avatar   string `json:"avatar,omitempty"`
 }
 
-func (s *Session) SetUsername(username string) (*User, error) {
-	return s.modifyCurrentUser(modifyCurrentUser{Username: username})
+func (s *Session) SetUsername(username string, options ...RequestOption) (*User, error) {
+	return s.modifyCurrentUser(modifyCurrentUser{Username: username}, options)
 }
 
-func (s *Session) SetAvatar(imageMimeType string, reader io.Reader) (*User, error) {
-	bytes, err := ioutil.ReadAll(reader)
-
+func (s *Session) SetAvatar(imageMimeType string, reader io.Reader, options ...RequestOption) (*User, error) {
+	avatar, err := ImageData(imageMimeType, reader)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.modifyCurrentUser(modifyCurrentUser{Avatar: avatar}, options)
+}
+
+// ImageData reads an image into the data URI format Discord expects for avatars, icons and splashes
+func ImageData(imageMimeType string, reader io.Reader) (string, error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
 	encoded := base64.StdEncoding.EncodeToString(bytes)
-	return s.modifyCurrentUser(modifyCurrentUser{Avatar: fmt.Sprintf("data:%s;base64,%s", imageMimeType, encoded)})
+	return fmt.Sprintf("data:%s;base64,%s", imageMimeType, encoded), nil
 }
 
-func (s *Session) modifyCurrentUser(modification modifyCurrentUser) (*User, error) {
+func (s *Session) modifyCurrentUser(modification modifyCurrentUser, options []RequestOption) (*User, error) {
 	user := &User{}
-	err := s.doHttpPatch(EndPointOwnUser(), &modification, user)
+	err := s.doHttpPatch(EndPointOwnUser(), &modification, user, options)
 	if err != nil {
 		return nil, err
 	}
@@ -100,13 +109,13 @@ func (s *shard) setSelfbotStatus() {
 	}}, false)
 }
 
-func (s *Session) GetDMChannel(userID Snowflake) (*Channel, error) {
+func (s *Session) GetDMChannel(userID Snowflake, options ...RequestOption) (*Channel, error) {
 	recipient := struct {
 		RecipientID Snowflake `json:"recipient_id"`
 	}{userID}
 
 	channel := &Channel{}
-	err := s.doHttpPost(EndPointDMChannels(), recipient, channel)
+	err := s.doHttpPost(EndPointDMChannels(), recipient, channel, options)
 	if err != nil {
 		return nil, err
 	}
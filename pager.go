@@ -0,0 +1,85 @@
+package disgo
+
+// pager holds the state every paginated iterator shares, the iterators themselves only know how to fetch a page
+type pager struct {
+	// fetch replaces the page of the iterator with the next one of at most limit items, and returns how many items it holds
+	fetch func(limit int) (int, error)
+
+	// pageSize is the most items Discord sends in a single page, minSize the least we may ask for
+	pageSize int
+	minSize  int
+	// limit caps the total amount of items, 0 walks through all of them
+	limit int
+	// fullPages always fetches as many items as possible, for iterators that skip items that don't count towards the limit
+	fullPages bool
+
+	size  int
+	index int
+	last  bool
+	done  bool
+	err   error
+	count int
+}
+
+func newPager(pageSize, limit int, fetch func(limit int) (int, error)) pager {
+	return pager{fetch: fetch, pageSize: pageSize, minSize: 1, limit: limit}
+}
+
+// next advances to the next item, fetching a new page when needed, index points at the item in the current page
+func (p *pager) next() bool {
+	if p.done || (p.limit > 0 && p.count >= p.limit) {
+		return false
+	}
+
+	// Fetch the next page once we have gone through the current one
+	if p.index+1 >= p.size {
+		if p.last {
+			return p.stop(nil)
+		}
+
+		limit := p.pageSize
+		if p.limit > 0 && !p.fullPages {
+			limit = clampLimit(p.limit-p.count, p.minSize, p.pageSize)
+		}
+
+		size, err := p.fetch(limit)
+		if err != nil {
+			return p.stop(err)
+		}
+
+		if size == 0 {
+			return p.stop(nil)
+		}
+
+		// A page that isn't full is the last one
+		p.size = size
+		p.index = -1
+		p.last = size < limit
+	}
+
+	p.index++
+	p.count++
+	return true
+}
+
+// skip makes the current item not count towards the limit
+func (p *pager) skip() {
+	p.count--
+}
+
+// stop ends the iteration, a non-nil err is returned by the Err method of the iterator
+func (p *pager) stop(err error) bool {
+	p.err = err
+	p.done = true
+	return false
+}
+
+func clampLimit(limit, min, max int) int {
+	if limit < min {
+		return min
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
@@ -0,0 +1,91 @@
+package disgo
+
+// SetChannelOverwrite creates the permission overwrite on a channel, or replaces it if the channel already has one for the same member or role
+func (s *Session) SetChannelOverwrite(channelID Snowflake, overwrite Overwrite, options ...RequestOption) error {
+	err := s.doHttpPut(EndPointChannelPermissions(channelID, overwrite.ID), overwrite, nil, options)
+	if err != nil {
+		return err
+	}
+
+	updateCachedOverwrites(channelID, func(overwrites []Overwrite) []Overwrite {
+		for i, existing := range overwrites {
+			if existing.ID == overwrite.ID {
+				overwrites[i] = overwrite
+				return overwrites
+			}
+		}
+		return append(overwrites, overwrite)
+	})
+	return nil
+}
+
+func (s *Session) SetMemberOverwrite(channelID, userID Snowflake, allow, deny int, options ...RequestOption) error {
+	return s.SetChannelOverwrite(channelID, Overwrite{ID: userID, Type: "member", Allow: allow, Deny: deny}, options...)
+}
+
+func (s *Session) SetRoleOverwrite(channelID, roleID Snowflake, allow, deny int, options ...RequestOption) error {
+	return s.SetChannelOverwrite(channelID, Overwrite{ID: roleID, Type: "role", Allow: allow, Deny: deny}, options...)
+}
+
+// DeleteChannelOverwrite removes the permission overwrite for a member or role from a channel
+func (s *Session) DeleteChannelOverwrite(channelID, overwriteID Snowflake, options ...RequestOption) error {
+	err := s.doHttpDelete(EndPointChannelPermissions(channelID, overwriteID), nil, options)
+	if err != nil {
+		return err
+	}
+
+	updateCachedOverwrites(channelID, func(overwrites []Overwrite) []Overwrite {
+		for i, existing := range overwrites {
+			if existing.ID == overwriteID {
+				return append(overwrites[:i], overwrites[i+1:]...)
+			}
+		}
+		return overwrites
+	})
+	return nil
+}
+
+// ReplaceChannelOverwrites replaces all permission overwrites of a channel in a single request
+func (s *Session) ReplaceChannelOverwrites(channelID Snowflake, overwrites []Overwrite, options ...RequestOption) (*Channel, error) {
+	return s.EditChannel(channelID).PermissionOverwrites(overwrites).Apply(options...)
+}
+
+func (s *Channel) SetOverwrite(overwrite Overwrite, options ...RequestOption) error {
+	return s.session.SetChannelOverwrite(s.ID(), overwrite, options...)
+}
+
+func (s *Channel) SetMemberOverwrite(userID Snowflake, allow, deny int, options ...RequestOption) error {
+	return s.session.SetMemberOverwrite(s.ID(), userID, allow, deny, options...)
+}
+
+func (s *Channel) SetRoleOverwrite(roleID Snowflake, allow, deny int, options ...RequestOption) error {
+	return s.session.SetRoleOverwrite(s.ID(), roleID, allow, deny, options...)
+}
+
+func (s *Channel) DeleteOverwrite(overwriteID Snowflake, options ...RequestOption) error {
+	return s.session.DeleteChannelOverwrite(s.ID(), overwriteID, options...)
+}
+
+func (s *Channel) ReplaceOverwrites(overwrites []Overwrite, options ...RequestOption) error {
+	_, err := s.session.ReplaceChannelOverwrites(s.ID(), overwrites, options...)
+	return err
+}
+
+// updateCachedOverwrites changes the overwrites of a cached channel, Discord doesn't return the channel for these calls.
+// The update works on a copy, as the current slice may have been handed out by PermissionOverwrites.
+func updateCachedOverwrites(channelID Snowflake, update func(overwrites []Overwrite) []Overwrite) {
+	objects.channelLock.RLock()
+	channel, exists := objects.channels[channelID]
+	objects.channelLock.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	channel.lock.Lock()
+	defer channel.lock.Unlock()
+
+	overwrites := make([]Overwrite, len(channel.internal.PermissionOverwrites))
+	copy(overwrites, channel.internal.PermissionOverwrites)
+	channel.internal.PermissionOverwrites = update(overwrites)
+}
@@ -0,0 +1,63 @@
+package disgo
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestChannelOverwrites(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"id":"6","name":"staff","permission_overwrites":[{"id":"1","type":"role","allow":0,"deny":1024}]}`))
+		case "PATCH":
+			w.Write([]byte(`{"id":"6","name":"staff","permission_overwrites":[{"id":"3","type":"role","allow":1024,"deny":0}]}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	channel, err := session.GetChannel(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handedOut := channel.PermissionOverwrites()
+
+	if err := channel.SetMemberOverwrite(2, 1024, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := channel.SetRoleOverwrite(1, 1024, 2048); err != nil {
+		t.Fatal(err)
+	}
+	if overwrites := channel.PermissionOverwrites(); len(overwrites) != 2 || overwrites[0].Deny != 2048 || overwrites[1].ID != 2 {
+		t.Fatalf("the cached overwrites were not updated: %+v", overwrites)
+	}
+	if handedOut[0].Deny != 1024 {
+		t.Fatal("a slice that was handed out earlier was changed")
+	}
+
+	if err := channel.DeleteOverwrite(1); err != nil {
+		t.Fatal(err)
+	}
+	if overwrites := channel.PermissionOverwrites(); len(overwrites) != 1 || overwrites[0].ID != 2 {
+		t.Fatalf("the deleted overwrite is still cached: %+v", overwrites)
+	}
+
+	if err := channel.ReplaceOverwrites([]Overwrite{{ID: 3, Type: "role", Allow: 1024}}); err != nil {
+		t.Fatal(err)
+	}
+	if overwrites := channel.PermissionOverwrites(); len(overwrites) != 1 || overwrites[0].ID != 3 {
+		t.Fatalf("the overwrites were not replaced: %+v", overwrites)
+	}
+
+	recorder.expect(t,
+		`GET /channels/6`,
+		`PUT /channels/6/permissions/2 {"id":"2","type":"member","allow":1024,"deny":0}`,
+		`PUT /channels/6/permissions/1 {"id":"1","type":"role","allow":1024,"deny":2048}`,
+		`DELETE /channels/6/permissions/1`,
+		`PATCH /channels/6 {"permission_overwrites":[{"id":"3","type":"role","allow":1024,"deny":0}]}`,
+	)
+}
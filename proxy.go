@@ -0,0 +1,153 @@
+package disgo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/slf4go/logger"
+)
+
+// Headers that only apply to a single connection, and should not be forwarded by the proxy
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// RESTProxy is a http.Handler that forwards REST calls to Discord using the token it was created with.
+// All calls going through the same proxy share its rate limits, see WithRESTProxy to make a session use one.
+type RESTProxy struct {
+	session *Session
+}
+
+// NewRESTProxy creates a proxy for the given bot token, options can be used to configure how it talks to Discord
+func NewRESTProxy(token string, options ...SessionOption) *RESTProxy {
+	return &RESTProxy{session: newSession("Bot ", token, false, options)}
+}
+
+// WithRESTProxy makes the session send all REST calls through the RESTProxy at the given url.
+// Rate limiting is left to the proxy.
+func WithRESTProxy(url string) SessionOption {
+	return func(s *Session) {
+		s.baseUrl = strings.TrimSuffix(url, "/")
+		s.rateLimiter = proxiedRateLimiter{}
+	}
+}
+
+// proxiedRateLimiter is used by sessions behind a RESTProxy, which already does the rate limiting for them
+type proxiedRateLimiter struct{}
+
+func (proxiedRateLimiter) Do(_ context.Context, _ RateLimitRequest, call func() (*http.Response, error)) error {
+	_, err := call()
+	return err
+}
+
+func (p *RESTProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// We have to be able to send the body again if Discord rate limits us
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	endPoint := parseEndPoint(r.URL.Path)
+	endPoint.Url = r.URL.RequestURI()
+
+	var response *http.Response
+	err = p.session.rateLimiter.Do(r.Context(), endPoint.rateLimitRequest(r.Method), func() (*http.Response, error) {
+		// We're only interested in the last response, so throw away the body of any previous attempt
+		if response != nil {
+			response.Body.Close()
+		}
+
+		req, err := http.NewRequestWithContext(r.Context(), r.Method, p.session.baseUrl+endPoint.Url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header = r.Header.Clone()
+		for _, header := range hopHeaders {
+			req.Header.Del(header)
+		}
+		req.Header.Set("Authorization", p.session.tokenType+p.session.token)
+		req.Header.Set("User-Agent", userAgent)
+
+		logger.Debugf("Proxying HTTP %s %s", r.Method, endPoint.Url)
+		response, err = p.session.httpClient.Do(req)
+		return response, err
+	})
+
+	if response == nil {
+		if err == nil {
+			err = fmt.Errorf("No response received for %s %s", r.Method, endPoint.Url)
+		}
+		logger.ErrorE(err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer response.Body.Close()
+
+	for header, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(header, value)
+		}
+	}
+	for _, header := range hopHeaders {
+		w.Header().Del(header)
+	}
+
+	w.WriteHeader(response.StatusCode)
+	if _, err := io.Copy(w, response.Body); err != nil {
+		logger.ErrorE(err)
+	}
+}
+
+// parseEndPoint determines the EndPoint of a request path the same way makeEndPoint does for known paths
+func parseEndPoint(path string) EndPoint {
+	var (
+		bucketID  string
+		bucketIDs = make([]interface{}, 0)
+		previous  string
+	)
+
+	for _, part := range strings.Split(path, "/") {
+		if part == "" {
+			continue
+		}
+
+		_, notSnowflake := ParseSnowflake(part)
+		switch {
+		case notSnowflake == nil && (previous == "channels" || previous == "guilds"):
+			// The major IDs are part of the bucket
+			bucketID += "/%s"
+			bucketIDs = append(bucketIDs, part)
+		case previous == "reactions":
+			// Emojis are generalized the same way as the EndPointReaction formats
+			bucketID += "/%%s"
+		case notSnowflake == nil || part == "@me":
+			bucketID += "/0"
+		default:
+			bucketID += "/" + part
+		}
+
+		previous = part
+	}
+
+	return EndPoint{
+		Url:       path,
+		bucket:    fmt.Sprintf(bucketID, bucketIDs...),
+		route:     bucketID,
+		major:     fmt.Sprint(bucketIDs...),
+		resetTime: -1,
+	}
+}
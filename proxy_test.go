@@ -0,0 +1,94 @@
+package disgo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseEndPoint(t *testing.T) {
+	reaction := EndPointOwnReaction(1, 2)
+	reaction.Url = fmt.Sprintf(reaction.Url, "%F0%9F%91%8D")
+
+	for _, expected := range []EndPoint{
+		EndPointGateway(),
+		EndPointChannel(1),
+		EndPointMessage(1, 2),
+		EndPointGuildMemberRoles(3, 4, 5),
+		EndPointGuildOwnNick(3),
+		EndPointOwnGuild(3),
+		reaction,
+	} {
+		if endPoint := parseEndPoint(expected.Url); endPoint != expected {
+			t.Errorf("parsed %+v from %s, expected %+v", endPoint, expected.Url, expected)
+		}
+	}
+}
+
+func TestRESTProxy(t *testing.T) {
+	var attempts int32
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bot proxytoken" {
+			t.Errorf("proxy sent authorization %q", auth)
+		}
+		if body, _ := ioutil.ReadAll(r.Body); string(body) != `{"content":"hi"}` {
+			t.Errorf("proxy sent body %q", body)
+		}
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "100")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Write([]byte(`{"id":"42"}`))
+	}))
+	defer discord.Close()
+
+	limiter, clock := newTestRateLimiter()
+	proxy := httptest.NewServer(NewRESTProxy("proxytoken", WithBaseUrl(discord.URL), WithRateLimiter(limiter)))
+	defer proxy.Close()
+
+	session := newSession("Bot ", "token", false, []SessionOption{WithRESTProxy(proxy.URL)})
+
+	target := IDObject{}
+	if err := session.doHttpPost(EndPointMessages(1), map[string]string{"content": "hi"}, &target, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if target.Id != 42 {
+		t.Fatalf("expected the response of the retried request, got id %d", target.Id)
+	}
+	if attempts != 2 || clock.Slept() == 0 {
+		t.Fatalf("expected the proxy to wait and retry the rate limited request, got %d attempts", attempts)
+	}
+}
+
+func TestRESTProxyEventHandlers(t *testing.T) {
+	// Event handlers are registered globally, so we start without any
+	defer func(registered map[string][]eventHandler) { handlers = registered }(handlers)
+	handlers = make(map[string][]eventHandler)
+
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"url":"wss://gateway.discord.gg","shards":1}`))
+	}))
+	defer discord.Close()
+
+	// A proxy only does REST calls, it must not handle the events of a bot running in the same process
+	NewRESTProxy("proxytoken", WithBaseUrl(discord.URL))
+	if _, err := NewBot("token", WithBaseUrl(discord.URL)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(handlers) == 0 {
+		t.Fatal("the bot did not register its handlers")
+	}
+	for name, registered := range handlers {
+		if len(registered) != 1 {
+			t.Errorf("expected one handler for %s, got %d", name, len(registered))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package disgo
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/slf4go/logger"
+)
+
+type pruneResult struct {
+	Pruned *int `json:"pruned"`
+}
+
+// GetPruneCount returns how many members would be removed by a prune, without removing them.
+// Members that haven't been seen for the amount of days and have no roles are pruned, includeRoles also prunes members with those roles.
+func (s *Session) GetPruneCount(guildID Snowflake, days int, includeRoles []Snowflake, options ...RequestOption) (int, error) {
+	result := pruneResult{}
+	if err := s.doHttpGet(pruneEndPoint(guildID, days, includeRoles, nil), &result, options); err != nil {
+		return 0, err
+	}
+
+	if result.Pruned == nil {
+		return 0, nil
+	}
+	return *result.Pruned, nil
+}
+
+func (s *Guild) GetPruneCount(days int, includeRoles []Snowflake, options ...RequestOption) (int, error) {
+	return s.session.GetPruneCount(s.internal.ID, days, includeRoles, options...)
+}
+
+// BeginPrune removes inactive members from the guild (see GetPruneCount) and returns how many were removed.
+// The cached members of the guild are fetched again afterwards, as Discord doesn't tell us who was removed,
+// failing to do so is only logged since the members were removed regardless.
+func (s *Session) BeginPrune(guildID Snowflake, days int, includeRoles []Snowflake, options ...RequestOption) (int, error) {
+	computeCount := true
+	result := pruneResult{}
+	if err := s.doHttpPost(pruneEndPoint(guildID, days, includeRoles, &computeCount), nil, &result, options); err != nil {
+		return 0, err
+	}
+
+	if result.Pruned == nil || *result.Pruned == 0 {
+		return 0, nil
+	}
+	s.refreshPrunedGuildMembers(guildID, options)
+	return *result.Pruned, nil
+}
+
+func (s *Guild) BeginPrune(days int, includeRoles []Snowflake, options ...RequestOption) (int, error) {
+	return s.session.BeginPrune(s.internal.ID, days, includeRoles, options...)
+}
+
+// BeginPruneWithoutCount is like BeginPrune, but doesn't make Discord count the removed members, which is recommended for large guilds
+func (s *Session) BeginPruneWithoutCount(guildID Snowflake, days int, includeRoles []Snowflake, options ...RequestOption) error {
+	computeCount := false
+	if err := s.doHttpPost(pruneEndPoint(guildID, days, includeRoles, &computeCount), nil, nil, options); err != nil {
+		return err
+	}
+
+	s.refreshPrunedGuildMembers(guildID, options)
+	return nil
+}
+
+func (s *Guild) BeginPruneWithoutCount(days int, includeRoles []Snowflake, options ...RequestOption) error {
+	return s.session.BeginPruneWithoutCount(s.internal.ID, days, includeRoles, options...)
+}
+
+func pruneEndPoint(guildID Snowflake, days int, includeRoles []Snowflake, computeCount *bool) EndPoint {
+	query := url.Values{}
+	query.Set("days", strconv.Itoa(days))
+	if computeCount != nil {
+		query.Set("compute_prune_count", strconv.FormatBool(*computeCount))
+	}
+	if len(includeRoles) != 0 {
+		roles := make([]string, len(includeRoles))
+		for i, roleID := range includeRoles {
+			roles[i] = roleID.String()
+		}
+		query.Set("include_roles", strings.Join(roles, ","))
+	}
+
+	endPoint := EndPointGuildPrune(guildID)
+	endPoint.Url += "?" + query.Encode()
+	return endPoint
+}
+
+func (s *Session) refreshPrunedGuildMembers(guildID Snowflake, options []RequestOption) {
+	if err := s.refreshGuildMembers(guildID, options); err != nil {
+		logger.Errorf("Could not refresh the members of guild %s after pruning: %s", guildID, err)
+	}
+}
+
+// refreshGuildMembers replaces the cached members of a guild with all of its current members, if we have the guild cached
+func (s *Session) refreshGuildMembers(guildID Snowflake, options []RequestOption) error {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	members := make([]*GuildMember, 0, len(guild.Members()))
+	iterator := s.ListGuildMembers(guildID, GuildMemberFilter{}, options...)
+	for iterator.Next() {
+		members = append(members, iterator.Member())
+	}
+	if err := iterator.Err(); err != nil {
+		return err
+	}
+
+	guild.lock.Lock()
+	guild.internal.Members = members
+	guild.lock.Unlock()
+	return nil
+}
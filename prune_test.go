@@ -0,0 +1,72 @@
+package disgo
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestPrune(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		switch r.URL.Path {
+		case "/guilds/800/prune":
+			w.Write([]byte(`{"pruned":1}`))
+		case "/guilds/800/members":
+			w.Write([]byte(`[{"user":{"id":"1"}}]`))
+		}
+	})
+
+	guild := newTestGuild(session, 800)
+	for _, id := range []Snowflake{1, 2} {
+		user := objects.registerUser(&User{internal: &internalUser{ID: id}, lock: new(sync.RWMutex)})
+		guild.internal.Members = append(guild.internal.Members, &GuildMember{internal: &internalGuildMember{User: user}})
+	}
+
+	if count, err := guild.GetPruneCount(7, []Snowflake{801, 802}); err != nil || count != 1 {
+		t.Fatalf("expected a prune count of 1, got %d: %v", count, err)
+	}
+	if len(guild.Members()) != 2 {
+		t.Fatal("counting pruned members should not change the cached members")
+	}
+
+	if count, err := guild.BeginPrune(7, nil); err != nil || count != 1 {
+		t.Fatalf("expected 1 pruned member, got %d: %v", count, err)
+	}
+	if _, exists := guild.GetUserMembership(2); exists || len(guild.Members()) != 1 {
+		t.Fatal("the pruned member is still cached")
+	}
+
+	recorder.expect(t,
+		"GET /guilds/800/prune?days=7&include_roles=801%2C802",
+		"POST /guilds/800/prune?compute_prune_count=true&days=7",
+		"GET /guilds/800/members?limit=1000",
+	)
+}
+
+func TestPruneWithoutMembers(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/guilds/810/members" {
+			http.Error(w, `{"code":50013,"message":"Missing Permissions"}`, http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"pruned":3}`))
+	})
+
+	guild := newTestGuild(session, 810)
+	user := objects.registerUser(&User{internal: &internalUser{ID: 1}, lock: new(sync.RWMutex)})
+	guild.internal.Members = []*GuildMember{{internal: &internalGuildMember{User: user}}}
+
+	// The members were pruned, even if we can't find out which ones
+	if count, err := guild.BeginPrune(7, nil); err != nil || count != 3 {
+		t.Fatalf("expected 3 pruned members, got %d: %v", count, err)
+	}
+	if err := guild.BeginPruneWithoutCount(7, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(guild.Members()) != 1 {
+		t.Fatal("the cached members were replaced although they could not be fetched")
+	}
+}
@@ -1,7 +1,8 @@
 package disgo
 
 import (
-	"errors"
+	"context"
+	"math"
 	"net/http"
 	"strconv"
 	"sync"
@@ -10,146 +11,362 @@ import (
 	"github.com/slf4go/logger"
 )
 
+const (
+	// Discord allows 50 requests per second across all endpoints, we stay under that before being told so
+	globalRequestLimit  = 50
+	globalRequestWindow = time.Second
+)
+
+// clock is the source of time for the rate limiter, so tests can replace it
+type clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
 type rateBucket struct {
+	// Only one request per bucket can be in flight, this channel acts as a lock that can be abandoned when the context is done
+	lock chan struct{}
+
+	// These fields may only be touched while holding the lock above
 	remaining int
 	limit     int
 	reset     time.Time
-	mutex     sync.Mutex
 }
 
-func (s *Session) rateLimit(endPoint EndPoint, call func() (*http.Response, error)) error {
-	return s.rateLimitRecursive(endPoint, call, false)
+func newRateBucket() *rateBucket {
+	return &rateBucket{lock: make(chan struct{}, 1), remaining: 1}
 }
 
-func (s *Session) rateLimitRecursive(endPoint EndPoint, call func() (*http.Response, error), recursive bool) error {
-	// Get the bucket, and if it does not exist, create it.
-	bucket, exists := s.rateLimitBuckets[endPoint.bucket]
-	if !exists {
-		bucket = &rateBucket{remaining: 1}
-		s.rateLimitBuckets[endPoint.bucket] = bucket
+// RateLimitRequest describes a single REST request to a RateLimiter
+type RateLimitRequest struct {
+	Method string `json:"method"`
+
+	// Route is the path of the endpoint without any parameters but the major ones, Discord's bucket hashes are learned per route
+	Route string `json:"route"`
+	Major string `json:"major"`
+
+	// Bucket is the bucket the request is assumed to belong to, until Discord tells us the real one
+	Bucket string `json:"bucket"`
+
+	// FixedReset replaces the reset time Discord sends for endpoints that are known to report it wrong, if set
+	FixedReset time.Duration `json:"fixed_reset,omitempty"`
+}
+
+func (e EndPoint) rateLimitRequest(method string) RateLimitRequest {
+	request := RateLimitRequest{Method: method, Route: e.route, Major: e.major, Bucket: e.bucket}
+	if e.resetTime != -1 {
+		request.FixedReset = time.Duration(e.resetTime) * time.Millisecond
 	}
+	return request
+}
+
+// RateLimiter decides when a REST request may be sent to Discord.
+// Do has to call call (again, if Discord rate limited it) until it either succeeds, fails or the context is done.
+type RateLimiter interface {
+	Do(ctx context.Context, request RateLimitRequest, call func() (*http.Response, error)) error
+}
 
-	// Lock this bucket
-	if !recursive {
-		bucket.mutex.Lock()
-		defer bucket.mutex.Unlock()
+// MemoryRateLimiter keeps track of all buckets within this process, it is the RateLimiter sessions use by default
+type MemoryRateLimiter struct {
+	clock clock
+
+	// Buckets are keyed by our own guess (RateLimitRequest.Bucket) until Discord tells us the real bucket hash for a route
+	registryLock sync.Mutex
+	buckets      map[string]*rateBucket
+	hashes       map[string]string
+
+	globalLock   sync.Mutex
+	globalReset  time.Time
+	globalWindow time.Time
+	globalCount  int
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		clock:   realClock{},
+		buckets: make(map[string]*rateBucket),
+		hashes:  make(map[string]string),
+	}
+}
+
+func (s *Session) rateLimit(ctx context.Context, method string, endPoint EndPoint, call func() (*http.Response, error)) error {
+	return s.rateLimiter.Do(ctx, endPoint.rateLimitRequest(method), call)
+}
+
+func (l *MemoryRateLimiter) Do(ctx context.Context, request RateLimitRequest, call func() (*http.Response, error)) error {
+	for {
+		bucket, err := l.acquire(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		// Okay, we've exhausted all possible ratelimit timers, let's send
+		response, err := call()
+
+		// If the request never reached Discord, there's nothing to read
+		retry := false
+		if response != nil {
+			retry = l.report(ctx, request, bucket, response.StatusCode, response.Header)
+		} else {
+			bucket.release()
+		}
+
+		if !retry {
+			return err
+		}
+	}
+}
+
+// acquire locks the bucket of the request and waits until the request may be sent, the bucket has to be released afterwards
+func (l *MemoryRateLimiter) acquire(ctx context.Context, request RateLimitRequest) (*rateBucket, error) {
+	// Get the bucket, and lock it
+	bucket := l.bucket(request)
+	select {
+	case bucket.lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
 	// Wait for the bucket to expire if we're out of attempts
-	now := time.Now()
+	now := l.clock.Now()
 	if bucket.remaining == 0 && bucket.reset.After(now) {
-		logger.Warnf("We are out of slots for %s, waiting...", endPoint.bucket)
-		time.Sleep(bucket.reset.Sub(now))
+		logger.Warnf("We are out of slots for %s, waiting...", request.Bucket)
+		if err := l.sleep(ctx, bucket.reset.Sub(now)); err != nil {
+			bucket.release()
+			return nil, err
+		}
 	}
 
-	// Once we're past the bucket lock, lock globally
-	if !recursive {
-		s.globalRateLimit.Lock()
-		defer s.globalRateLimit.Unlock()
+	// Once we're past the bucket, wait for the global limit
+	if err := l.waitGlobal(ctx); err != nil {
+		bucket.release()
+		return nil, err
 	}
 
-	// Wait for the globalRateLimit lock if we're being globally rate limited
-	now = time.Now()
-	if s.globalReset.After(now) {
-		logger.Warnf("We are waiting for the globalRateLimit...")
-		time.Sleep(s.globalReset.Sub(now))
-	}
+	return bucket, nil
+}
+
+func (b *rateBucket) release() {
+	<-b.lock
+}
 
-	// Okay, we've exhausted all possible ratelimit timers, let's send
-	response, err := call()
-	now = time.Now()
+// report reads the response of a request into its bucket and releases it, and reports whether the request has to be sent again
+func (l *MemoryRateLimiter) report(ctx context.Context, request RateLimitRequest, bucket *rateBucket, statusCode int, header http.Header) bool {
+	// If Discord put the route in a bucket we already know, the response belongs to that bucket rather than to our guess
+	if hash := header.Get("X-RateLimit-Bucket"); hash != "" {
+		if known := l.discover(request, hash, bucket); known != bucket {
+			bucket.release()
+			select {
+			case known.lock <- struct{}{}:
+				bucket = known
+			case <-ctx.Done():
+				// Retrying makes the caller run into the context error
+				return true
+			}
+		}
+	}
+	defer bucket.release()
 
-	// Not all returned error status codes include rate limit headers, if that is the case we return now
+	retry, err := l.update(request, bucket, statusCode, header)
 	if err != nil {
-		switch response.StatusCode {
-		case http.StatusBadRequest:
-			fallthrough
-		case http.StatusUnauthorized:
-			fallthrough
-		case http.StatusForbidden:
-			fallthrough
-		case http.StatusMethodNotAllowed:
-			return err
+		logger.Errorf("Could not read the rate limit headers for %s: %s", request.Bucket, err)
+	}
+	return retry
+}
+
+// bucket returns the bucket a request belongs to, creating it if we haven't seen it yet
+func (l *MemoryRateLimiter) bucket(request RateLimitRequest) *rateBucket {
+	l.registryLock.Lock()
+	defer l.registryLock.Unlock()
+
+	key := request.Bucket
+	if hash, exists := l.hashes[request.Method+" "+request.Route]; exists {
+		key = hash + ":" + request.Major
+	}
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = newRateBucket()
+		l.buckets[key] = bucket
+	}
+
+	return bucket
+}
+
+// discover remembers the bucket hash Discord sent us for a route, so other routes with the same hash share the bucket.
+// It returns the bucket that is registered for the hash, which is only the given bucket if the hash was not known yet.
+func (l *MemoryRateLimiter) discover(request RateLimitRequest, hash string, bucket *rateBucket) *rateBucket {
+	l.registryLock.Lock()
+	defer l.registryLock.Unlock()
+
+	l.hashes[request.Method+" "+request.Route] = hash
+
+	key := hash + ":" + request.Major
+	if known, exists := l.buckets[key]; exists {
+		return known
+	}
+
+	l.buckets[key] = bucket
+	return bucket
+}
+
+// waitGlobal blocks until we are allowed to send another request, either after a global 429 or when we'd exceed the global limit
+func (l *MemoryRateLimiter) waitGlobal(ctx context.Context) error {
+	for {
+		l.globalLock.Lock()
+		now := l.clock.Now()
+
+		var wait time.Duration
+		if l.globalReset.After(now) {
+			wait = l.globalReset.Sub(now)
+			logger.Warnf("We are waiting for the global rate limit...")
+		} else {
+			windowEnd := l.globalWindow.Add(globalRequestWindow)
+			if !now.Before(windowEnd) {
+				l.globalWindow = now
+				l.globalCount = 0
+			}
+
+			if l.globalCount < globalRequestLimit {
+				l.globalCount++
+				l.globalLock.Unlock()
+				return nil
+			}
+
+			wait = windowEnd.Sub(now)
+			logger.Debugf("Sent %d requests this second, waiting...", l.globalCount)
 		}
+		l.globalLock.Unlock()
 
-		if response.StatusCode > 500 {
+		if err := l.sleep(ctx, wait); err != nil {
 			return err
 		}
 	}
+}
+
+// sleep waits for the given duration, or returns early with the context error if it is done first
+func (l *MemoryRateLimiter) sleep(ctx context.Context, duration time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case <-l.clock.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// update reads the rate limit headers into the bucket, and reports whether the request has to be sent again
+func (l *MemoryRateLimiter) update(request RateLimitRequest, bucket *rateBucket, statusCode int, header http.Header) (bool, error) {
+	now := l.clock.Now()
 
 	// Read the headers
 	var (
-		headerDiscordTime = response.Header.Get("Date")
-		headerRemaining   = response.Header.Get("X-RateLimit-Remaining")
-		headerLimit       = response.Header.Get("X-RateLimit-Limit")
-		headerReset       = response.Header.Get("X-RateLimit-Reset")
-		headerRetryAfter  = response.Header.Get("Retry-After")
-		headerGlobal      = response.Header.Get("X-RateLimit-Global")
+		headerDiscordTime = header.Get("Date")
+		headerBucket      = header.Get("X-RateLimit-Bucket")
+		headerRemaining   = header.Get("X-RateLimit-Remaining")
+		headerLimit       = header.Get("X-RateLimit-Limit")
+		headerReset       = header.Get("X-RateLimit-Reset")
+		headerResetAfter  = header.Get("X-RateLimit-Reset-After")
+		headerRetryAfter  = header.Get("Retry-After")
+		headerGlobal      = header.Get("X-RateLimit-Global")
 	)
 
-	logger.Tracef("Ratelimit headers: remaining: %s, limit: %s, reset: %s, retryAfter: %s", headerRemaining, headerLimit, headerReset, headerRetryAfter)
+	logger.Tracef("Ratelimit headers: bucket: %s, remaining: %s, limit: %s, reset: %s, resetAfter: %s, retryAfter: %s", headerBucket, headerRemaining, headerLimit, headerReset, headerResetAfter, headerRetryAfter)
 
 	// Are we being rate limited because of that last request?
-	if response.StatusCode == http.StatusTooManyRequests {
+	if statusCode == http.StatusTooManyRequests {
 		if headerRetryAfter == "" {
-			return errors.New("We are being ratelimited, but Discord didn't send a Retry-After header")
+			logger.Error("We are being ratelimited, but Discord didn't send a Retry-After header")
+			return false, nil
 		}
 
 		retryAfter, err := strconv.Atoi(headerRetryAfter)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		resetTime := now.Add(time.Duration(retryAfter) * time.Millisecond)
 
 		if headerGlobal == "true" {
 			logger.Error("We are being globally ratelimited!")
-			s.globalReset = resetTime
+			l.globalLock.Lock()
+			l.globalReset = resetTime
+			l.globalLock.Unlock()
 		} else {
-			logger.Errorf("We are being ratelimited on %s!", endPoint.bucket)
+			logger.Errorf("We are being ratelimited on %s!", request.Bucket)
 			bucket.reset = resetTime
 			bucket.remaining = 0
 		}
 
-		return s.rateLimitRecursive(endPoint, call, true)
+		return true, nil
 	}
 
-	// Nope, not rate limited, but let's update our bucket first
-	var parseError error
+	// Nope, not rate limited, but let's update our bucket
 	if headerRemaining != "" {
-		bucket.remaining, parseError = strconv.Atoi(headerRemaining)
-		if parseError != nil {
-			return parseError
+		remaining, err := strconv.Atoi(headerRemaining)
+		if err != nil {
+			return false, err
 		}
+		bucket.remaining = remaining
 	}
 	if headerLimit != "" {
-		bucket.limit, parseError = strconv.Atoi(headerLimit)
-		if parseError != nil {
-			return parseError
+		limit, err := strconv.Atoi(headerLimit)
+		if err != nil {
+			return false, err
 		}
+		bucket.limit = limit
 	}
-	if endPoint.resetTime == -1 {
-		if headerReset != "" {
-			unix, parseError := strconv.ParseInt(headerReset, 10, 64)
-			if parseError != nil {
-				return parseError
-			}
-			resetTime := time.Unix(unix, 0)
-
-			if headerDiscordTime == "" {
-				bucket.reset = resetTime
-			} else {
-				discordTime, parseError := time.Parse(time.RFC1123, headerDiscordTime)
-				if parseError != nil {
-					return parseError
-				}
-				bucket.reset = now.Add(resetTime.Sub(discordTime))
+
+	switch {
+	case request.FixedReset != 0:
+		bucket.reset = now.Add(request.FixedReset)
+	case headerResetAfter != "":
+		// Reset-After is relative, so we don't have to care about clock differences between us and Discord
+		resetAfter, err := parseSeconds(headerResetAfter)
+		if err != nil {
+			return false, err
+		}
+		bucket.reset = now.Add(resetAfter)
+	case headerReset != "":
+		reset, err := parseSeconds(headerReset)
+		if err != nil {
+			return false, err
+		}
+		resetTime := time.Unix(0, 0).Add(reset)
+
+		if headerDiscordTime == "" {
+			bucket.reset = resetTime
+		} else {
+			discordTime, err := time.Parse(time.RFC1123, headerDiscordTime)
+			if err != nil {
+				return false, err
 			}
+			bucket.reset = now.Add(resetTime.Sub(discordTime))
 		}
-	} else {
-		bucket.reset = now.Add(time.Duration(endPoint.resetTime) * time.Millisecond)
 	}
 
-	// If we did encounter a http response that did include ratelimit headers, we return here after reading the headers
-	return err
+	return false, nil
+}
+
+// parseSeconds parses the (fractional) seconds Discord uses in its rate limit headers with millisecond precision
+func parseSeconds(header string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(header, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(math.Round(seconds*1000)) * time.Millisecond, nil
 }
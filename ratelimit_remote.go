@@ -0,0 +1,253 @@
+package disgo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slf4go/logger"
+)
+
+const (
+	// A lease that isn't released within this time (for example because its process died) is released by the server,
+	// it only has to outlast a single request with the default client timeout of 10 seconds.
+	rateLimitLeaseTimeout = 15 * time.Second
+
+	// Until a lease is released nobody else can use its bucket, so we try a few times before leaving it to expire
+	rateLimitReleaseAttempts = 3
+)
+
+type rateLimitAcquireResponse struct {
+	Lease string `json:"lease"`
+}
+
+type rateLimitReleaseRequest struct {
+	Lease      string      `json:"lease"`
+	StatusCode int         `json:"status_code,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+}
+
+type rateLimitReleaseResponse struct {
+	Retry bool `json:"retry"`
+}
+
+// RemoteRateLimiter asks a RateLimitServer in another process for permission before every request,
+// so multiple processes sharing a token also share their rate limits.
+type RemoteRateLimiter struct {
+	url    string
+	client *http.Client
+}
+
+// NewRemoteRateLimiter creates a RateLimiter that uses the RateLimitServer listening at url, if client is nil http.DefaultClient is used
+func NewRemoteRateLimiter(url string, client *http.Client) *RemoteRateLimiter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RemoteRateLimiter{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+func (l *RemoteRateLimiter) Do(ctx context.Context, request RateLimitRequest, call func() (*http.Response, error)) error {
+	for {
+		acquired := rateLimitAcquireResponse{}
+		if err := l.post(ctx, "/acquire", request, &acquired); err != nil {
+			return err
+		}
+
+		response, err := call()
+
+		// If the request never reached Discord there's nothing to report, but the lease still has to be released
+		release := rateLimitReleaseRequest{Lease: acquired.Lease}
+		if response != nil {
+			release.StatusCode = response.StatusCode
+			release.Header = response.Header
+		}
+
+		// The request has been made, so we release the lease even if the context is done by now
+		released := rateLimitReleaseResponse{}
+		var releaseErr error
+		for attempt := 0; attempt < rateLimitReleaseAttempts; attempt++ {
+			if releaseErr = l.post(context.Background(), "/release", &release, &released); releaseErr == nil {
+				break
+			}
+		}
+		if releaseErr != nil {
+			logger.Errorf("Could not report to the rate limit server, the bucket stays locked until the lease expires: %s", releaseErr)
+		}
+
+		if !released.Retry {
+			return err
+		}
+	}
+}
+
+func (l *RemoteRateLimiter) post(ctx context.Context, path string, body, target interface{}) error {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", l.url+path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("Rate limit server replied with status code %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return json.NewDecoder(response.Body).Decode(target)
+}
+
+type rateLimitLease struct {
+	request RateLimitRequest
+	bucket  *rateBucket
+	expire  *time.Timer
+}
+
+// RateLimitServer makes a MemoryRateLimiter available to RemoteRateLimiters in other processes over HTTP
+type RateLimitServer struct {
+	limiter *MemoryRateLimiter
+
+	leaseLock sync.Mutex
+	leases    map[string]*rateLimitLease
+}
+
+func NewRateLimitServer(limiter *MemoryRateLimiter) *RateLimitServer {
+	return &RateLimitServer{limiter: limiter, leases: make(map[string]*rateLimitLease)}
+}
+
+func (s *RateLimitServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/acquire":
+		s.acquire(w, r)
+	case "/release":
+		s.release(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *RateLimitServer) acquire(w http.ResponseWriter, r *http.Request) {
+	request := RateLimitRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// If the client goes away while we're waiting, the request context is cancelled and we stop waiting
+	bucket, err := s.limiter.acquire(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	// The client may have given up just as we acquired the bucket, nobody would release it then
+	if err := r.Context().Err(); err != nil {
+		bucket.release()
+		return
+	}
+
+	id, err := newLeaseID()
+	if err != nil {
+		bucket.release()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	lease := &rateLimitLease{request: request, bucket: bucket}
+	lease.expire = time.AfterFunc(rateLimitLeaseTimeout, func() {
+		if s.takeLease(id) != nil {
+			logger.Warnf("Rate limit lease for %s expired without being released", request.Bucket)
+			bucket.release()
+		}
+	})
+
+	s.leaseLock.Lock()
+	s.leases[id] = lease
+	s.leaseLock.Unlock()
+
+	// If the client never receives the lease it can't release it either, so we do that right away
+	if err := writeJSON(w, rateLimitAcquireResponse{Lease: id}); err != nil && s.takeLease(id) != nil {
+		lease.expire.Stop()
+		bucket.release()
+	}
+}
+
+func (s *RateLimitServer) release(w http.ResponseWriter, r *http.Request) {
+	release := rateLimitReleaseRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lease := s.takeLease(release.Lease)
+	if lease == nil {
+		http.Error(w, "Unknown or expired lease", http.StatusNotFound)
+		return
+	}
+	lease.expire.Stop()
+
+	retry := false
+	if release.StatusCode != 0 {
+		retry = s.limiter.report(r.Context(), lease.request, lease.bucket, release.StatusCode, release.Header)
+	} else {
+		lease.bucket.release()
+	}
+
+	writeJSON(w, rateLimitReleaseResponse{Retry: retry})
+}
+
+// takeLease removes a lease from the server, it returns nil if it was already taken
+func (s *RateLimitServer) takeLease(id string) *rateLimitLease {
+	s.leaseLock.Lock()
+	defer s.leaseLock.Unlock()
+
+	lease, exists := s.leases[id]
+	if !exists {
+		return nil
+	}
+
+	delete(s.leases, id)
+	return lease
+}
+
+func newLeaseID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.ErrorE(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,386 @@
+package disgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock never actually sleeps, it moves time forward by however long it was asked to wait
+type fakeClock struct {
+	lock  sync.Mutex
+	now   time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) After(duration time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(duration)
+	c.slept += duration
+
+	fired := make(chan time.Time, 1)
+	fired <- c.now
+	return fired
+}
+
+func (c *fakeClock) Slept() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.slept
+}
+
+func newTestSession(t *testing.T, handler http.HandlerFunc) (*Session, *fakeClock) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	// Cached objects keep the session they were first seen by, so every test starts with an empty cache
+	objects = &state{
+		users:    make(map[Snowflake]*User),
+		guilds:   make(map[Snowflake]*Guild),
+		channels: make(map[Snowflake]*Channel),
+		messages: make(map[Snowflake]*Message),
+		roles:    make(map[Snowflake]*Role),
+	}
+
+	limiter, clock := newTestRateLimiter()
+	session := newSession("Bot ", "token", false, []SessionOption{WithBaseUrl(server.URL), WithRateLimiter(limiter)})
+
+	return session, clock
+}
+
+// newTestGuild caches an empty guild that belongs to the session, tests fill in whatever else they need
+func newTestGuild(session *Session, id Snowflake) *Guild {
+	guild := objects.registerGuild(&Guild{internal: &internalGuild{ID: id}, lock: new(sync.RWMutex)})
+	guild.session = session
+	return guild
+}
+
+// requestRecorder keeps track of the requests a test server received, so a test can check them afterwards
+type requestRecorder struct {
+	lock     sync.Mutex
+	requests []string
+}
+
+// record stores the method, URI and body (if any) of a request
+func (r *requestRecorder) record(request *http.Request) {
+	line := request.Method + " " + request.URL.RequestURI()
+	if body, _ := ioutil.ReadAll(request.Body); len(body) != 0 {
+		line += " " + string(body)
+	}
+
+	r.lock.Lock()
+	r.requests = append(r.requests, line)
+	r.lock.Unlock()
+}
+
+func (r *requestRecorder) Requests() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]string(nil), r.requests...)
+}
+
+// expect fails the test unless exactly the expected requests were recorded, in order
+func (r *requestRecorder) expect(t *testing.T, expected ...string) {
+	t.Helper()
+
+	requests := r.Requests()
+	if len(requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d: %q", len(expected), len(requests), requests)
+	}
+	for i, request := range expected {
+		if requests[i] != request {
+			t.Errorf("expected request %q, got %q", request, requests[i])
+		}
+	}
+}
+
+func newTestRateLimiter() (*MemoryRateLimiter, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(1500000000, 0)}
+	limiter := NewMemoryRateLimiter()
+	limiter.clock = clock
+
+	return limiter, clock
+}
+
+func TestRateLimitBucketDiscovery(t *testing.T) {
+	session, clock := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Limit", "1")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "1.337")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if slept := clock.Slept(); slept != 1337*time.Millisecond {
+		t.Fatalf("expected to wait 1.337s for the bucket to reset, waited %s", slept)
+	}
+
+	// A different route that Discord puts in the same bucket should end up sharing it
+	if err := session.doHttpGet(EndPointChannelPins(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	limiter := session.rateLimiter.(*MemoryRateLimiter)
+	if limiter.bucket(EndPointChannel(1).rateLimitRequest("GET")) != limiter.bucket(EndPointChannelPins(1).rateLimitRequest("GET")) {
+		t.Fatal("routes with the same bucket hash do not share their bucket")
+	}
+	if limiter.bucket(EndPointChannel(1).rateLimitRequest("GET")) == limiter.bucket(EndPointChannel(2).rateLimitRequest("GET")) {
+		t.Fatal("routes with different major parameters share their bucket")
+	}
+}
+
+func TestRateLimitKnownBucket(t *testing.T) {
+	var attempts int32
+	session, clock := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		if r.URL.Path == "/channels/1/pins" && atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "700")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The pins route is only now found to share the bucket, the 429 has to end up in the shared bucket the retry uses
+	if err := session.doHttpGet(EndPointChannelPins(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if slept := clock.Slept(); slept != 700*time.Millisecond {
+		t.Fatalf("expected to wait 700ms before retrying, waited %s", slept)
+	}
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	var attempts int32
+	session, clock := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"content":"hi"}` {
+			t.Errorf("attempt %d was sent with body %q", atomic.LoadInt32(&attempts)+1, body)
+		}
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "250")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message":"You are being rate limited.","retry_after":250,"global":false}`))
+			return
+		}
+
+		w.Write([]byte(`{"id":"42"}`))
+	})
+
+	target := IDObject{}
+	if err := session.doHttpPost(EndPointMessages(1), map[string]string{"content": "hi"}, &target, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	if target.Id != 42 {
+		t.Fatalf("expected the response of the retried request, got id %d", target.Id)
+	}
+	if slept := clock.Slept(); slept != 250*time.Millisecond {
+		t.Fatalf("expected to wait 250ms before retrying, waited %s", slept)
+	}
+}
+
+func TestRateLimitGlobal(t *testing.T) {
+	session, clock := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Every request goes to its own bucket, so only the global limit applies
+	for i := 0; i < globalRequestLimit+10; i++ {
+		if err := session.doHttpGet(EndPointChannel(Snowflake(i)), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if slept := clock.Slept(); slept != globalRequestWindow {
+		t.Fatalf("expected to wait %s for the global limit, waited %s", globalRequestWindow, slept)
+	}
+}
+
+func TestRateLimitGlobal429(t *testing.T) {
+	var attempts int32
+	session, clock := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1500")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.doHttpGet(EndPointGuild(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if slept := clock.Slept(); slept != 1500*time.Millisecond {
+		t.Fatalf("expected to wait 1.5s for the global rate limit, waited %s", slept)
+	}
+}
+
+func TestRateLimitContext(t *testing.T) {
+	var attempts int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "10")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := session.doHttpGet(EndPointChannel(1), nil, []RequestOption{WithContext(ctx)}); err != context.Canceled {
+		t.Fatalf("expected the request to be canceled, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected the canceled request to never reach Discord, got %d requests", attempts)
+	}
+}
+
+func TestRateLimitConcurrent(t *testing.T) {
+	var requests int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("X-RateLimit-Bucket", r.URL.Path)
+		w.Header().Set("X-RateLimit-Remaining", "1")
+		w.Header().Set("X-RateLimit-Reset-After", "0.250")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := session.doHttpGet(EndPointChannel(Snowflake(i%4)), nil, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if requests != 40 {
+		t.Fatalf("expected 40 requests, got %d", requests)
+	}
+}
+
+func TestRemoteRateLimiter(t *testing.T) {
+	limiter, clock := newTestRateLimiter()
+	rateLimitServer := NewRateLimitServer(limiter)
+	limitServer := httptest.NewServer(rateLimitServer)
+	defer limitServer.Close()
+
+	var attempts int32
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "abcd")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset-After", "2")
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "500")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer discord.Close()
+
+	// Two sessions, as if they were running in separate processes
+	sessions := make([]*Session, 2)
+	for i := range sessions {
+		sessions[i] = newSession("Bot ", "token", false, []SessionOption{
+			WithBaseUrl(discord.URL),
+			WithRateLimiter(NewRemoteRateLimiter(limitServer.URL, nil)),
+		})
+	}
+
+	for _, session := range sessions {
+		if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 requests, got %d", attempts)
+	}
+	if slept := clock.Slept(); slept != 2500*time.Millisecond {
+		t.Fatalf("expected the second session to wait for the bucket of the first, waited %s", slept)
+	}
+	if len(rateLimitServer.leases) != 0 {
+		t.Fatal("expected all leases to be released")
+	}
+}
+
+func TestRateLimitServerAbandonedAcquire(t *testing.T) {
+	limiter, _ := newTestRateLimiter()
+	rateLimitServer := NewRateLimitServer(limiter)
+
+	// The client is already gone by the time the server gets to the request, so the bucket must not stay locked
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	request := EndPointChannel(1).rateLimitRequest("GET")
+	body, _ := json.Marshal(request)
+	rateLimitServer.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/acquire", bytes.NewReader(body)).WithContext(ctx))
+
+	if len(rateLimitServer.leases) != 0 {
+		t.Fatal("expected no lease to be handed out")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	bucket, err := limiter.acquire(ctx, request)
+	if err != nil {
+		t.Fatalf("expected the bucket to be released: %s", err)
+	}
+	bucket.release()
+}
@@ -0,0 +1,113 @@
+package disgo
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GetVoiceRegions lists the voice regions that can be used when creating guilds
+func (s *Session) GetVoiceRegions(options ...RequestOption) ([]*VoiceRegion, error) {
+	return s.getVoiceRegions(EndPointVoiceRegions(), options)
+}
+
+// GetGuildVoiceRegions lists the voice regions available to a guild, including VIP regions if the guild has access to them
+func (s *Session) GetGuildVoiceRegions(guildID Snowflake, options ...RequestOption) ([]*VoiceRegion, error) {
+	return s.getVoiceRegions(EndPointGuildRegions(guildID), options)
+}
+
+func (s *Guild) GetVoiceRegions(options ...RequestOption) ([]*VoiceRegion, error) {
+	return s.session.GetGuildVoiceRegions(s.internal.ID, options...)
+}
+
+func (s *Session) getVoiceRegions(endPoint EndPoint, options []RequestOption) ([]*VoiceRegion, error) {
+	regions := make([]*VoiceRegion, 0)
+	if err := s.doHttpGet(endPoint, &regions, options); err != nil {
+		return nil, err
+	}
+
+	for _, region := range regions {
+		region.session = s
+	}
+	return regions, nil
+}
+
+// GuildEmbed holds the widget settings of a guild, ChannelID is the channel the widget invites to, 0 for none
+type GuildEmbed struct {
+	Enabled   bool      `json:"enabled"`
+	ChannelID Snowflake `json:"channel_id"`
+}
+
+func (s *Session) GetGuildEmbed(guildID Snowflake, options ...RequestOption) (GuildEmbed, error) {
+	embed := GuildEmbed{}
+	if err := s.doHttpGet(EndPointGuildEmbed(guildID), &embed, options); err != nil {
+		return GuildEmbed{}, err
+	}
+
+	updateCachedGuildEmbed(guildID, embed)
+	return embed, nil
+}
+
+func (s *Guild) GetEmbed(options ...RequestOption) (GuildEmbed, error) {
+	return s.session.GetGuildEmbed(s.internal.ID, options...)
+}
+
+// EditGuildEmbed changes the widget settings of a guild, it returns the settings as Discord stored them
+func (s *Session) EditGuildEmbed(guildID Snowflake, embed GuildEmbed, options ...RequestOption) (GuildEmbed, error) {
+	changes := map[string]interface{}{"enabled": embed.Enabled, "channel_id": nil}
+	if embed.ChannelID != 0 {
+		changes["channel_id"] = embed.ChannelID
+	}
+
+	updated := GuildEmbed{}
+	if err := s.doHttpPatch(EndPointGuildEmbed(guildID), changes, &updated, options); err != nil {
+		return GuildEmbed{}, err
+	}
+
+	updateCachedGuildEmbed(guildID, updated)
+	return updated, nil
+}
+
+func (s *Guild) EditEmbed(embed GuildEmbed, options ...RequestOption) (GuildEmbed, error) {
+	return s.session.EditGuildEmbed(s.internal.ID, embed, options...)
+}
+
+func updateCachedGuildEmbed(guildID Snowflake, embed GuildEmbed) {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if exists {
+		guild.lock.Lock()
+		guild.internal.EmbedEnabled = embed.Enabled
+		guild.internal.EmbedChannelID = embed.ChannelID
+		guild.lock.Unlock()
+	}
+}
+
+type WidgetStyle string
+
+const (
+	WidgetStyleShield  WidgetStyle = "shield"
+	WidgetStyleBanner1 WidgetStyle = "banner1"
+	WidgetStyleBanner2 WidgetStyle = "banner2"
+	WidgetStyleBanner3 WidgetStyle = "banner3"
+	WidgetStyleBanner4 WidgetStyle = "banner4"
+)
+
+// WidgetJSONURL returns the public URL of the widget data of a guild, it only works while the widget is enabled
+func WidgetJSONURL(guildID Snowflake) string {
+	return fmt.Sprintf("%s/guilds/%s/widget.json", BaseUrl, guildID)
+}
+
+// WidgetImageURL returns the public URL of an image of the widget of a guild, it only works while the widget is enabled
+func WidgetImageURL(guildID Snowflake, style WidgetStyle) string {
+	return fmt.Sprintf("%s/guilds/%s/widget.png?style=%s", BaseUrl, guildID, url.QueryEscape(string(style)))
+}
+
+func (s *Guild) WidgetJSONURL() string {
+	return WidgetJSONURL(s.internal.ID)
+}
+
+func (s *Guild) WidgetImageURL(style WidgetStyle) string {
+	return WidgetImageURL(s.internal.ID, style)
+}
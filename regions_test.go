@@ -0,0 +1,49 @@
+package disgo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestVoiceRegions(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/guilds/1000/regions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"eu-west","name":"EU West","optimal":true},{"id":"us-vip","name":"US VIP","vip":true,"deprecated":true}]`))
+	})
+
+	regions, err := session.GetGuildVoiceRegions(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(regions) != 2 || regions[0].ID() != "eu-west" || !regions[0].Optimal() || !regions[1].VIP() || !regions[1].Deprecated() {
+		t.Fatalf("unexpected regions: %v", regions)
+	}
+}
+
+func TestGuildEmbed(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"channel_id":null,"enabled":true}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Write([]byte(`{"enabled":true,"channel_id":null}`))
+	})
+
+	guild := newTestGuild(session, 1001)
+	guild.internal.EmbedChannelID = 5
+
+	embed, err := guild.EditEmbed(GuildEmbed{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !embed.Enabled || !guild.EmbedEnabled() || guild.EmbedChannelID() != 0 {
+		t.Fatalf("the cached guild was not updated: %+v", embed)
+	}
+
+	if url := guild.WidgetImageURL(WidgetStyleBanner2); url != BaseUrl+"/guilds/1001/widget.png?style=banner2" {
+		t.Fatalf("unexpected widget image URL: %s", url)
+	}
+}
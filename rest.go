@@ -2,13 +2,14 @@ package disgo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
 	"unicode/utf8"
 
 	"mime/multipart"
@@ -16,13 +17,18 @@ import (
 	"github.com/slf4go/logger"
 )
 
+const userAgent = "DiscordBot (https://github.com/ikkerens/disgo, 1.0.0)"
+
 type EndPoint struct {
 	Url       string
 	bucket    string
+	route     string
+	major     string
 	resetTime int
 }
 
 var (
+	// BaseUrl is the default API location used by new sessions, see WithBaseUrl to override it per session
 	BaseUrl = "https://discordapp.com/api/v" + gatewayVersion
 
 	EndPointGateway      = makeEndPoint("/gateway")
@@ -61,6 +67,9 @@ var (
 	EndPointGuildIntegration     = makeEndPoint("/guilds/:guild_id/integrations/:integration_id")
 	EndPointGuildIntegrationSync = makeEndPoint("/guilds/:guild_id/integrations/:integration_id/sync")
 	EndPointGuildEmbed           = makeEndPoint("/guilds/:guild_id/embed")
+	EndPointGuildAuditLogs       = makeEndPoint("/guilds/:guild_id/audit-logs")
+
+	EndPointInvite = makeEndPoint("/invites/%%s")
 
 	EndPointOwnUser    = makeEndPoint("/users/@me")
 	EndPointUser       = makeEndPoint("/users/:user_id")
@@ -74,21 +83,17 @@ func makeEndPoint(path string) func(ids ...Snowflake) EndPoint {
 	parts := strings.Split(path, "/")
 
 	capacity := strings.Count(path, ":")
-	snowflakes := make([]Snowflake, 0, capacity)
-	endPoint := BaseUrl
-	endPointIDs := make([]interface{}, 0, capacity)
+	variables := 0
+	endPoint := ""
 	bucketID := ""
-	bucketIDs := make([]interface{}, 0, capacity)
+	majorIndices := make([]int, 0, capacity)
 
 	for _, part := range parts {
 		if utf8.RuneCountInString(part) != 0 {
 			// For every variable in the path
 			if part[:1] == ":" {
-				snowflakes = append(snowflakes, Snowflake(42))
-
 				// Add a format
 				endPoint += "/%s"
-				endPointIDs = append(endPointIDs, &snowflakes[len(snowflakes)-1])
 
 				// If we're dealing with one of the "major" IDs, we can consider this part of the bucket
 				switch part[1:] {
@@ -96,11 +101,13 @@ func makeEndPoint(path string) func(ids ...Snowflake) EndPoint {
 					fallthrough
 				case "channel_id":
 					bucketID += "/%s"
-					bucketIDs = append(bucketIDs, &snowflakes[len(snowflakes)-1])
+					majorIndices = append(majorIndices, variables)
 				default:
 					// Otherwise, generalize it with a simple zero, an ID that *should* never occur
 					bucketID += "/0"
 				}
+
+				variables++
 			} else if part == "@me" {
 				endPoint += "/" + part
 				bucketID += "/0"
@@ -115,90 +122,197 @@ func makeEndPoint(path string) func(ids ...Snowflake) EndPoint {
 
 	// The idea is that any part of the library can create an EndPoint with their snowflakes
 	return func(ids ...Snowflake) EndPoint {
-		for i, snowflake := range ids {
-			snowflakes[i] = snowflake
+		// Every call gets its own set of arguments, so endpoints can safely be made from multiple goroutines
+		endPointIDs := make([]interface{}, variables)
+		for i := range endPointIDs {
+			if i < len(ids) {
+				endPointIDs[i] = ids[i]
+			} else {
+				endPointIDs[i] = Snowflake(42)
+			}
+		}
+
+		bucketIDs := make([]interface{}, len(majorIndices))
+		for i, index := range majorIndices {
+			bucketIDs[i] = endPointIDs[index]
 		}
 
 		// But only the rest api functions themselves should decide whether they need the bucket ID or the Url
-		return EndPoint{fmt.Sprintf(endPoint, endPointIDs...), fmt.Sprintf(bucketID, bucketIDs...), -1}
+		return EndPoint{
+			Url:       fmt.Sprintf(endPoint, endPointIDs...),
+			bucket:    fmt.Sprintf(bucketID, bucketIDs...),
+			route:     bucketID,
+			major:     fmt.Sprint(bucketIDs...),
+			resetTime: -1,
+		}
 	}
 }
 
-func (s *Session) doHttpGet(endPoint EndPoint, target interface{}) (err error) {
-	err = s.rateLimit(endPoint, func() (*http.Response, error) {
-		return s.doRequest("GET", endPoint.Url, "", nil, target)
-	})
-	return
+// RequestOption can be passed to any Session REST call to change how that single request is made
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	ctx    context.Context
+	reason string
 }
 
-func (s *Session) doHttpDelete(endPoint EndPoint, target interface{}) (err error) {
-	err = s.rateLimit(endPoint, func() (*http.Response, error) {
-		return s.doRequest("DELETE", endPoint.Url, "", nil, target)
-	})
-	return
+// WithContext makes the request (including any rate limit waits) stop once the given context is done
+func WithContext(ctx context.Context) RequestOption {
+	return func(c *requestConfig) {
+		c.ctx = ctx
+	}
 }
 
-func (s *Session) doHttpPut(endPoint EndPoint, target interface{}) (err error) {
-	err = s.rateLimit(endPoint, func() (*http.Response, error) {
-		return s.doRequest("PUT", endPoint.Url, "", nil, target)
-	})
-	return
+// WithReason adds a reason to the request, which shows up in the audit log of the guild for any changes it makes
+func WithReason(reason string) RequestOption {
+	return func(c *requestConfig) {
+		c.reason = reason
+	}
 }
 
-func (s *Session) doHttpPost(endPoint EndPoint, body, target interface{}) (err error) {
-	jsonBody, err := json.Marshal(body)
+func newRequestConfig(options []RequestOption) *requestConfig {
+	config := &requestConfig{ctx: context.Background()}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
 
-	if err == nil {
-		byteBuf := bytes.NewReader(jsonBody)
-		err = s.rateLimit(endPoint, func() (*http.Response, error) {
-			return s.doRequest("POST", endPoint.Url, "application/json", byteBuf, target)
-		})
+func (s *Session) doHttpGet(endPoint EndPoint, target interface{}, options []RequestOption) error {
+	return s.doHttp(newRequestConfig(options), "GET", endPoint, "", nil, target)
+}
+
+func (s *Session) doHttpDelete(endPoint EndPoint, target interface{}, options []RequestOption) error {
+	return s.doHttp(newRequestConfig(options), "DELETE", endPoint, "", nil, target)
+}
+
+func (s *Session) doHttpPut(endPoint EndPoint, body, target interface{}, options []RequestOption) error {
+	if body == nil {
+		return s.doHttp(newRequestConfig(options), "PUT", endPoint, "", nil, target)
 	}
 
-	return
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	return s.doHttp(newRequestConfig(options), "PUT", endPoint, "application/json", bytesBody(jsonBody), target)
 }
 
-func (s *Session) doHttMultipartPost(endPoint EndPoint, bodyWriter func(writer *multipart.Writer) error, target interface{}) (err error) {
-	var buffer bytes.Buffer
-	mpW := multipart.NewWriter(&buffer)
+func (s *Session) doHttpPost(endPoint EndPoint, body, target interface{}, options []RequestOption) error {
+	if body == nil {
+		return s.doHttp(newRequestConfig(options), "POST", endPoint, "", nil, target)
+	}
 
-	err = bodyWriter(mpW)
+	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return
+		return err
 	}
-	mpW.Close()
 
-	err = s.rateLimit(endPoint, func() (*http.Response, error) {
-		return s.doRequest("POST", endPoint.Url, mpW.FormDataContentType(), &buffer, target)
-	})
+	return s.doHttp(newRequestConfig(options), "POST", endPoint, "application/json", bytesBody(jsonBody), target)
+}
 
-	return
+// doHttMultipartPost streams the JSON payload and the files to Discord as a multipart body instead of buffering it.
+// The body is written again for every attempt, rewinding the files if needed.
+func (s *Session) doHttMultipartPost(endPoint EndPoint, payload []byte, files []*MessageFile, target interface{}, options []RequestOption) error {
+	// Where every file started belongs to this request, so the same MessageFile can be part of multiple requests
+	uploads := make([]fileUpload, len(files))
+	for i, file := range files {
+		uploads[i].MessageFile = file
+	}
+
+	// Every attempt uses the same boundary, so the content type stays the same
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+	contentType := "multipart/form-data; boundary=" + boundary
+
+	var (
+		previousReader *io.PipeReader
+		previousDone   chan struct{}
+	)
+	body := func() io.Reader {
+		retry := previousReader != nil
+		if retry {
+			// Make sure the writer of the previous attempt is done before the files are read again
+			previousReader.Close()
+			<-previousDone
+		}
+
+		reader, writer := io.Pipe()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			mpW := multipart.NewWriter(writer)
+			mpW.SetBoundary(boundary)
+
+			err := mpW.WriteField("payload_json", string(payload))
+			for i := 0; err == nil && i < len(uploads); i++ {
+				err = uploads[i].writeTo(mpW, fmt.Sprintf("file%d", i), retry)
+			}
+			if err == nil {
+				err = mpW.Close()
+			}
+			writer.CloseWithError(err)
+		}()
+
+		previousReader, previousDone = reader, done
+		return reader
+	}
+
+	return s.doHttp(newRequestConfig(options), "POST", endPoint, contentType, body, target)
 }
 
-func (s *Session) doHttpPatch(endPoint EndPoint, body, target interface{}) (err error) {
+func (s *Session) doHttpPatch(endPoint EndPoint, body, target interface{}, options []RequestOption) error {
 	jsonBody, err := json.Marshal(body)
-
-	if err == nil {
-		byteBuf := bytes.NewReader(jsonBody)
-		err = s.rateLimit(endPoint, func() (*http.Response, error) {
-			return s.doRequest("PATCH", endPoint.Url, "application/json", byteBuf, target)
-		})
+	if err != nil {
+		return err
 	}
 
-	return
+	return s.doHttp(newRequestConfig(options), "PATCH", endPoint, "application/json", bytesBody(jsonBody), target)
 }
 
-func (s *Session) doRequest(method, url string, contentType string, body io.Reader, target interface{}) (response *http.Response, err error) {
-	logger.Debugf("HTTP %s %s", method, strings.Replace(url, BaseUrl, "", 1))
+// bytesBody returns a body func that gives every attempt a fresh reader, as a previous attempt will have consumed the last one
+func bytesBody(body []byte) func() io.Reader {
+	return func() io.Reader {
+		return bytes.NewReader(body)
+	}
+}
 
-	var (
-		req    *http.Request
-		client = http.Client{
-			Timeout: 10 * time.Second,
+// doHttp sends a request through the rate limiter, and retries it according to the retry policy of the session
+func (s *Session) doHttp(config *requestConfig, method string, endPoint EndPoint, contentType string, body func() io.Reader, target interface{}) error {
+	for attempt := 1; ; attempt++ {
+		err := s.rateLimit(config.ctx, method, endPoint, func() (*http.Response, error) {
+			var reader io.Reader
+			if body != nil {
+				reader = body()
+			}
+			return s.doRequest(config, method, endPoint, contentType, reader, target)
+		})
+
+		if err == nil || config.ctx.Err() != nil || !s.retryPolicy.shouldRetry(method, attempt, err) {
+			return err
 		}
-	)
 
-	if req, err = http.NewRequest(method, url, body); err != nil {
+		delay := s.retryPolicy.backoff(attempt)
+		logger.Warnf("HTTP %s %s failed (attempt %d of %d), retrying in %s: %s", method, endPoint.Url, attempt, s.retryPolicy.MaxAttempts, delay, err)
+		if s.retryPolicy.OnRetry != nil {
+			s.retryPolicy.OnRetry(RetryAttempt{Method: method, EndPoint: endPoint.Url, Attempt: attempt, Delay: delay, Err: err})
+		}
+
+		if err := sleepContext(config.ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Session) doRequest(config *requestConfig, method string, endPoint EndPoint, contentType string, body io.Reader, target interface{}) (response *http.Response, err error) {
+	logger.Debugf("HTTP %s %s", method, endPoint.Url)
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(config.ctx, method, s.baseUrl+endPoint.Url, body); err != nil {
+		if closer, ok := body.(io.Closer); ok {
+			closer.Close()
+		}
 		return
 	}
 
@@ -206,14 +320,21 @@ func (s *Session) doRequest(method, url string, contentType string, body io.Read
 		req.Header.Add("Content-Type", contentType)
 	}
 	req.Header.Add("Authorization", s.tokenType+s.token)
-	req.Header.Add("User-Agent", "DiscordBot (https://github.com/ikkerens/disgo, 1.0.0)")
+	req.Header.Add("User-Agent", userAgent)
+	if config.reason != "" {
+		req.Header.Add("X-Audit-Log-Reason", url.PathEscape(config.reason))
+	}
 
-	if response, err = client.Do(req); err != nil {
+	if response, err = s.httpClient.Do(req); err != nil {
 		logger.ErrorE(err)
 		return
 	}
 
-	defer response.Body.Close()
+	// Drain whatever is left of the body, so the connection can be reused by the client
+	defer func() {
+		io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
 
 	switch response.StatusCode {
 	case http.StatusOK:
@@ -235,7 +356,7 @@ func (s *Session) doRequest(method, url string, contentType string, body io.Read
 		if err != nil {
 			return
 		}
-		return response, fmt.Errorf("Discord replied with status code %d: %s", response.StatusCode, string(bodyBuf))
+		return response, newRESTError(method, endPoint, response, bodyBuf)
 	}
 
 	return
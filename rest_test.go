@@ -0,0 +1,72 @@
+package disgo
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransientStatus(t *testing.T) {
+	var attempts int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Write([]byte(`{"id":"42"}`))
+	})
+
+	var retries []RetryAttempt
+	policy := DefaultRetryPolicy
+	policy.BaseDelay = time.Millisecond
+	policy.OnRetry = func(attempt RetryAttempt) {
+		retries = append(retries, attempt)
+	}
+	WithRetryPolicy(policy)(session)
+
+	target := IDObject{}
+	if err := session.doHttpGet(EndPointChannel(1), &target, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if target.Id != 42 || attempts != 3 {
+		t.Fatalf("expected the third attempt to succeed, got id %d after %d attempts", target.Id, attempts)
+	}
+	if len(retries) != 2 || retries[1].Attempt != 2 || !errors.Is(retries[1].Err, &RESTError{StatusCode: http.StatusBadGateway}) {
+		t.Fatalf("expected 2 retries to be reported, got %+v", retries)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	var attempts int32
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if err := session.doHttpPost(EndPointMessages(1), map[string]string{"content": "hi"}, nil, nil); err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a POST to be sent once, got %d attempts", attempts)
+	}
+}
+
+func TestAuditLogReason(t *testing.T) {
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if reason := r.Header.Get("X-Audit-Log-Reason"); reason != "raid%20cleanup%20%2F%20%C3%A9" {
+			t.Errorf("expected an encoded audit log reason, got %q", reason)
+		}
+		if reason := r.URL.Query().Get("reason"); reason != "raid cleanup / é" {
+			t.Errorf("expected the ban reason in the query, got %q", reason)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := session.BanUser(1, 2, 0, WithReason("raid cleanup / é")); err != nil {
+		t.Fatal(err)
+	}
+}
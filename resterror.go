@@ -0,0 +1,227 @@
+package disgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrorCode is one of the JSON error codes Discord returns along with a failed request
+type ErrorCode int
+
+const (
+	ErrorCodeGeneral               ErrorCode = 0
+	ErrorCodeUnknownAccount        ErrorCode = 10001
+	ErrorCodeUnknownApplication    ErrorCode = 10002
+	ErrorCodeUnknownChannel        ErrorCode = 10003
+	ErrorCodeUnknownGuild          ErrorCode = 10004
+	ErrorCodeUnknownIntegration    ErrorCode = 10005
+	ErrorCodeUnknownInvite         ErrorCode = 10006
+	ErrorCodeUnknownMember         ErrorCode = 10007
+	ErrorCodeUnknownMessage        ErrorCode = 10008
+	ErrorCodeUnknownOverwrite      ErrorCode = 10009
+	ErrorCodeUnknownProvider       ErrorCode = 10010
+	ErrorCodeUnknownRole           ErrorCode = 10011
+	ErrorCodeUnknownToken          ErrorCode = 10012
+	ErrorCodeUnknownUser           ErrorCode = 10013
+	ErrorCodeUnknownEmoji          ErrorCode = 10014
+	ErrorCodeBotsNotAllowed        ErrorCode = 20001
+	ErrorCodeOnlyBotsAllowed       ErrorCode = 20002
+	ErrorCodeMaxGuilds             ErrorCode = 30001
+	ErrorCodeMaxFriends            ErrorCode = 30002
+	ErrorCodeMaxPins               ErrorCode = 30003
+	ErrorCodeMaxRoles              ErrorCode = 30005
+	ErrorCodeMaxReactions          ErrorCode = 30010
+	ErrorCodeUnauthorized          ErrorCode = 40001
+	ErrorCodeMissingAccess         ErrorCode = 50001
+	ErrorCodeInvalidAccountType    ErrorCode = 50002
+	ErrorCodeCannotExecuteOnDM     ErrorCode = 50003
+	ErrorCodeEmbedDisabled         ErrorCode = 50004
+	ErrorCodeCannotEditOthers      ErrorCode = 50005
+	ErrorCodeCannotSendEmpty       ErrorCode = 50006
+	ErrorCodeCannotMessageUser     ErrorCode = 50007
+	ErrorCodeCannotSendInVoice     ErrorCode = 50008
+	ErrorCodeVerificationTooHigh   ErrorCode = 50009
+	ErrorCodeOAuth2NoBot           ErrorCode = 50010
+	ErrorCodeOAuth2Limit           ErrorCode = 50011
+	ErrorCodeInvalidOAuth2State    ErrorCode = 50012
+	ErrorCodeMissingPermissions    ErrorCode = 50013
+	ErrorCodeInvalidToken          ErrorCode = 50014
+	ErrorCodeNoteTooLong           ErrorCode = 50015
+	ErrorCodeBulkDeleteAmount      ErrorCode = 50016
+	ErrorCodeCannotPinOtherChannel ErrorCode = 50019
+	ErrorCodeSystemMessage         ErrorCode = 50021
+	ErrorCodeBulkDeleteTooOld      ErrorCode = 50034
+	ErrorCodeInvalidFormBody       ErrorCode = 50035
+	ErrorCodeReactionBlocked       ErrorCode = 90001
+)
+
+// Sentinel errors that can be compared against any error returned from a Session REST call using errors.Is
+var (
+	ErrBadRequest   = &RESTError{StatusCode: http.StatusBadRequest}
+	ErrUnauthorized = &RESTError{StatusCode: http.StatusUnauthorized}
+	ErrForbidden    = &RESTError{StatusCode: http.StatusForbidden}
+	ErrNotFound     = &RESTError{StatusCode: http.StatusNotFound}
+	ErrRateLimited  = &RESTError{StatusCode: http.StatusTooManyRequests}
+
+	ErrUnknownChannel     = &RESTError{Code: ErrorCodeUnknownChannel}
+	ErrUnknownGuild       = &RESTError{Code: ErrorCodeUnknownGuild}
+	ErrUnknownInvite      = &RESTError{Code: ErrorCodeUnknownInvite}
+	ErrUnknownMember      = &RESTError{Code: ErrorCodeUnknownMember}
+	ErrUnknownMessage     = &RESTError{Code: ErrorCodeUnknownMessage}
+	ErrUnknownRole        = &RESTError{Code: ErrorCodeUnknownRole}
+	ErrUnknownUser        = &RESTError{Code: ErrorCodeUnknownUser}
+	ErrMissingAccess      = &RESTError{Code: ErrorCodeMissingAccess}
+	ErrMissingPermissions = &RESTError{Code: ErrorCodeMissingPermissions}
+	ErrCannotMessageUser  = &RESTError{Code: ErrorCodeCannotMessageUser}
+	ErrInvalidFormBody    = &RESTError{Code: ErrorCodeInvalidFormBody}
+)
+
+// FieldError describes why Discord rejected a single field of the request body
+type FieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RESTError is returned by every Session REST call that received a non-2xx response from Discord
+type RESTError struct {
+	// The HTTP method and endpoint of the failed request
+	Method   string
+	EndPoint string
+	Bucket   string
+
+	// The HTTP status code and the JSON error Discord replied with
+	StatusCode int
+	Code       ErrorCode
+	Message    string
+
+	// Validation errors keyed by the path of the offending field, for example "embed.fields.0.name"
+	Errors map[string][]FieldError
+
+	// The unparsed response body
+	Body []byte
+}
+
+func newRESTError(method string, endPoint EndPoint, response *http.Response, body []byte) *RESTError {
+	err := &RESTError{
+		Method:     method,
+		EndPoint:   endPoint.Url,
+		Bucket:     endPoint.bucket,
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}
+
+	payload := struct {
+		Code    ErrorCode       `json:"code"`
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}{}
+
+	if json.Unmarshal(body, &payload) != nil {
+		// Not a JSON error (for example a gateway timeout page), keep the body as the message
+		err.Message = strings.TrimSpace(string(body))
+		return err
+	}
+
+	err.Code = payload.Code
+	err.Message = payload.Message
+	if len(payload.Errors) != 0 {
+		err.Errors = make(map[string][]FieldError)
+		flattenFieldErrors(payload.Errors, "", err.Errors)
+	}
+
+	return err
+}
+
+// Discord nests validation errors following the structure of the request body, we flatten those into dotted paths
+func flattenFieldErrors(raw json.RawMessage, path string, target map[string][]FieldError) {
+	var nested map[string]json.RawMessage
+	if json.Unmarshal(raw, &nested) != nil {
+		return
+	}
+
+	for key, value := range nested {
+		if key == "_errors" {
+			var fieldErrors []FieldError
+			if json.Unmarshal(value, &fieldErrors) == nil {
+				target[path] = append(target[path], fieldErrors...)
+			}
+			continue
+		}
+
+		if path == "" {
+			flattenFieldErrors(value, key, target)
+		} else {
+			flattenFieldErrors(value, path+"."+key, target)
+		}
+	}
+}
+
+func (e *RESTError) Error() string {
+	msg := fmt.Sprintf("%s %s: Discord replied with status code %d", e.Method, e.EndPoint, e.StatusCode)
+	if e.Code != ErrorCodeGeneral {
+		msg += fmt.Sprintf(" (error code %d)", e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if len(e.Errors) != 0 {
+		paths := make([]string, 0, len(e.Errors))
+		for path := range e.Errors {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			for _, fieldError := range e.Errors[path] {
+				msg += fmt.Sprintf("; %s: %s", path, fieldError.Message)
+			}
+		}
+	}
+
+	return msg
+}
+
+// Is makes errors.Is match a RESTError against the sentinel errors, by JSON error code if the sentinel has one, otherwise by status code
+func (e *RESTError) Is(target error) bool {
+	t, ok := target.(*RESTError)
+	if !ok {
+		return false
+	}
+
+	if t.Code != ErrorCodeGeneral {
+		return t.Code == e.Code
+	}
+
+	return t.StatusCode != 0 && t.StatusCode == e.StatusCode
+}
+
+// IsNotFound reports whether the error was caused by a resource that does not (or no longer) exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsMissingPermissions reports whether the error was caused by the bot lacking the permissions or access for the call
+func IsMissingPermissions(err error) bool {
+	return errors.Is(err, ErrMissingPermissions) || errors.Is(err, ErrMissingAccess)
+}
+
+// IsUnauthorized reports whether the error was caused by an invalid token
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether the error was caused by a rate limit that could not be waited out
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsErrorCode reports whether the error is a RESTError with the given JSON error code
+func IsErrorCode(err error, code ErrorCode) bool {
+	var restError *RESTError
+	return errors.As(err, &restError) && restError.Code == code
+}
@@ -0,0 +1,64 @@
+package disgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func newTestRESTError(statusCode int, body string) error {
+	return newRESTError("POST", EndPointMessages(1), &http.Response{StatusCode: statusCode}, []byte(body))
+}
+
+func TestRESTError(t *testing.T) {
+	err := newTestRESTError(http.StatusBadRequest, `{"code": 50035, "message": "Invalid Form Body", "errors": {"embed": {"fields": {"0": {"name": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}}}}`)
+
+	var restError *RESTError
+	if !errors.As(err, &restError) {
+		t.Fatalf("expected a RESTError, got %T: %v", err, err)
+	}
+	if !errors.Is(err, ErrInvalidFormBody) || !errors.Is(err, ErrBadRequest) || IsNotFound(err) {
+		t.Fatalf("RESTError does not match the right sentinels: %v", err)
+	}
+	if restError.Method != "POST" || restError.EndPoint != "/channels/1/messages" {
+		t.Fatalf("RESTError does not describe the request: %v", err)
+	}
+	if fieldErrors := restError.Errors["embed.fields.0.name"]; len(fieldErrors) != 1 || fieldErrors[0].Code != "BASE_TYPE_REQUIRED" {
+		t.Fatalf("expected the nested field error to be flattened, got %+v", restError.Errors)
+	}
+}
+
+func TestRESTErrorSentinels(t *testing.T) {
+	notFound := newTestRESTError(http.StatusNotFound, `{"code": 10008, "message": "Unknown Message"}`)
+	forbidden := newTestRESTError(http.StatusForbidden, `{"code": 50013, "message": "Missing Permissions"}`)
+	noAccess := newTestRESTError(http.StatusForbidden, `{"code": 50001, "message": "Missing Access"}`)
+	gatewayTimeout := newTestRESTError(http.StatusGatewayTimeout, "<html>Gateway Timeout</html>")
+
+	// Status-only sentinels match on the status code, whatever the JSON error code is
+	if !IsNotFound(notFound) || !errors.Is(notFound, ErrUnknownMessage) || IsNotFound(forbidden) {
+		t.Fatal("IsNotFound does not match on the status code")
+	}
+	if !errors.Is(forbidden, ErrForbidden) || errors.Is(forbidden, ErrNotFound) {
+		t.Fatal("a 403 does not match the status-only sentinels correctly")
+	}
+
+	// Sentinels with an error code only match that code
+	if !IsMissingPermissions(forbidden) || !IsMissingPermissions(noAccess) || IsMissingPermissions(notFound) {
+		t.Fatal("IsMissingPermissions does not match on the error code")
+	}
+	if errors.Is(forbidden, ErrMissingAccess) || !IsErrorCode(noAccess, ErrorCodeMissingAccess) {
+		t.Fatal("error code sentinels match other error codes")
+	}
+
+	// Wrapped errors still match
+	if wrapped := fmt.Errorf("deleting message: %w", notFound); !IsNotFound(wrapped) {
+		t.Fatal("a wrapped RESTError does not match its sentinels")
+	}
+
+	// A body that isn't JSON is kept as the message
+	var restError *RESTError
+	if !errors.As(gatewayTimeout, &restError) || restError.Message != "<html>Gateway Timeout</html>" || !errors.Is(gatewayTimeout, &RESTError{StatusCode: http.StatusGatewayTimeout}) {
+		t.Fatalf("unexpected error for a non-JSON body: %v", gatewayTimeout)
+	}
+}
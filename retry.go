@@ -0,0 +1,133 @@
+package disgo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides which failed REST calls are sent again, and how long to wait before doing so.
+// Rate limited (429) requests are always retried by the rate limiter, regardless of this policy.
+type RetryPolicy struct {
+	// The maximum amount of times a request is sent, 1 or less disables retries
+	MaxAttempts int
+
+	// The delay before the first retry, which doubles for every next retry up to MaxDelay.
+	// The actual delay is randomized between half and all of it, so retries of many requests don't line up.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Discord replies with these status codes are retried
+	StatusCodes []int
+
+	// Errors that didn't come with a reply from Discord are retried if this returns true, defaults to IsTransientError
+	RetryableError func(err error) bool
+
+	// POST requests are not idempotent, a retry may for example send a message twice, so they are only retried if this is set
+	RetryNonIdempotent bool
+
+	// Called before every retry
+	OnRetry func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes a failed request that is about to be retried
+type RetryAttempt struct {
+	Method   string
+	EndPoint string
+	Attempt  int
+	Delay    time.Duration
+	Err      error
+}
+
+// DefaultRetryPolicy is used by sessions that have not been given a policy using WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	StatusCodes: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for the session
+func WithRetryPolicy(policy RetryPolicy) SessionOption {
+	return func(s *Session) {
+		s.retryPolicy = policy
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+
+	// A refused connection means the request was never sent, so even a POST can safely be sent again
+	if method == "POST" && !p.RetryNonIdempotent && !errors.Is(err, syscall.ECONNREFUSED) {
+		return false
+	}
+
+	var restError *RESTError
+	if errors.As(err, &restError) {
+		for _, statusCode := range p.StatusCodes {
+			if restError.StatusCode == statusCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return IsTransientError(err)
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// IsTransientError reports whether a request failed because of a timeout or a dropped connection
+func IsTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError) && netError.Timeout()
+}
+
+// sleepContext waits for the given duration, or returns early with the context error if it is done first
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
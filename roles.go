@@ -0,0 +1,159 @@
+package disgo
+
+// BuildRole creates a builder for a new role in a guild
+func (s *Session) BuildRole(guildID Snowflake, name string) *RoleBuilder {
+	return &RoleBuilder{
+		session: s,
+		guildID: guildID,
+
+		Name: name,
+	}
+}
+
+func (s *Guild) BuildRole(name string) *RoleBuilder {
+	return s.session.BuildRole(s.internal.ID, name)
+}
+
+// GetRoles fetches all roles of a guild, and replaces the cached roles of the guild with them
+func (s *Session) GetRoles(guildID Snowflake, options ...RequestOption) ([]*Role, error) {
+	roles := make([]*Role, 0)
+	if err := s.doHttpGet(EndPointGuildRoles(guildID), &roles, options); err != nil {
+		return nil, err
+	}
+
+	return s.registerGuildRoles(guildID, roles), nil
+}
+
+func (s *Guild) GetRoles(options ...RequestOption) ([]*Role, error) {
+	return s.session.GetRoles(s.internal.ID, options...)
+}
+
+// EditRole creates a builder for changes to a role, only the fields that are set on it are sent to Discord
+func (s *Session) EditRole(guildID, roleID Snowflake) *RoleEditBuilder {
+	return &RoleEditBuilder{
+		session: s,
+		guildID: guildID,
+		roleID:  roleID,
+		changes: make(map[string]interface{}),
+	}
+}
+
+func (s *Role) Edit() *RoleEditBuilder {
+	return s.session.EditRole(s.GuildID(), s.ID())
+}
+
+func (s *Session) DeleteRole(guildID, roleID Snowflake, options ...RequestOption) error {
+	if err := s.doHttpDelete(EndPointGuildRole(guildID, roleID), nil, options); err != nil {
+		return err
+	}
+
+	removeCachedGuildRole(guildID, roleID)
+	return nil
+}
+
+func (s *Role) Delete(options ...RequestOption) error {
+	return s.session.DeleteRole(s.GuildID(), s.ID(), options...)
+}
+
+// RolePosition moves a role to a new position when passed to ReorderRoles
+type RolePosition struct {
+	ID       Snowflake `json:"id"`
+	Position int       `json:"position"`
+}
+
+// ReorderRoles changes the positions of multiple roles at once, it returns all roles of the guild in their new order
+func (s *Session) ReorderRoles(guildID Snowflake, positions []RolePosition, options ...RequestOption) ([]*Role, error) {
+	roles := make([]*Role, 0)
+	if err := s.doHttpPatch(EndPointGuildRoles(guildID), positions, &roles, options); err != nil {
+		return nil, err
+	}
+
+	return s.registerGuildRoles(guildID, roles), nil
+}
+
+func (s *Guild) ReorderRoles(positions []RolePosition, options ...RequestOption) ([]*Role, error) {
+	return s.session.ReorderRoles(s.internal.ID, positions, options...)
+}
+
+// registerGuildRoles registers a full set of roles of a guild, the cached guild gets the registered roles
+func (s *Session) registerGuildRoles(guildID Snowflake, roles []*Role) []*Role {
+	for i, role := range roles {
+		roles[i] = s.registerGuildRole(guildID, role)
+	}
+
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if exists {
+		guild.lock.Lock()
+		guild.internal.Roles = append(make([]*Role, 0, len(roles)), roles...)
+		guild.lock.Unlock()
+	}
+
+	return roles
+}
+
+func (s *Session) registerGuildRole(guildID Snowflake, role *Role) *Role {
+	role = objects.registerRole(role)
+	if role.session == nil {
+		role.session = s
+	}
+
+	role.lock.Lock()
+	role.internal.GuildID = guildID
+	role.lock.Unlock()
+
+	return role
+}
+
+// addCachedGuildRole adds a role to the cached guild, if we have it and it doesn't know the role yet
+func addCachedGuildRole(guildID Snowflake, role *Role) {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	// The role may already be cached (for example by RoleBuilder.Create), the guild should hold that one
+	role = objects.registerRole(role)
+	role.lock.Lock()
+	role.internal.GuildID = guildID
+	role.lock.Unlock()
+
+	guild.lock.Lock()
+	defer guild.lock.Unlock()
+
+	for _, existing := range guild.internal.Roles {
+		if existing.internal.ID == role.internal.ID {
+			return
+		}
+	}
+
+	// The slice may have been handed out by Roles, so we don't append to it in place
+	roles := make([]*Role, 0, len(guild.internal.Roles)+1)
+	guild.internal.Roles = append(append(roles, guild.internal.Roles...), role)
+}
+
+func removeCachedGuildRole(guildID, roleID Snowflake) {
+	objects.guildLock.RLock()
+	guild, exists := objects.guilds[guildID]
+	objects.guildLock.RUnlock()
+
+	if !exists {
+		return
+	}
+
+	guild.lock.Lock()
+	defer guild.lock.Unlock()
+
+	roles := make([]*Role, 0, len(guild.internal.Roles))
+	for _, role := range guild.internal.Roles {
+		if role.internal.ID != roleID {
+			roles = append(roles, role)
+		}
+	}
+	guild.internal.Roles = roles
+}
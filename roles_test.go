@@ -0,0 +1,78 @@
+package disgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestRoles(t *testing.T) {
+	recorder := &requestRecorder{}
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		recorder.record(r)
+
+		switch {
+		case r.Method == "POST":
+			w.Write([]byte(`{"id":"302","name":"mods","color":255,"position":2}`))
+		case r.Method == "PATCH" && r.URL.Path == "/guilds/300/roles/302":
+			w.Write([]byte(`{"id":"302","name":"admins","color":255,"position":2}`))
+		case r.Method == "PATCH":
+			w.Write([]byte(`[{"id":"301","name":"@everyone","position":0},{"id":"302","name":"admins","color":255,"position":1}]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	guild := newTestGuild(session, 300)
+	guild.internal.Roles = []*Role{objects.registerRole(&Role{internal: &internalRole{ID: 301, Name: "@everyone"}, lock: new(sync.RWMutex)})}
+
+	builder := guild.BuildRole("mods")
+	builder.Color = 255
+	role, err := builder.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found, exists := guild.Role(302); !exists || found != role || role.GuildID() != 300 {
+		t.Fatal("the created role was not added to the guild")
+	}
+
+	// Discord also sends an event for the role we just created
+	event := GuildRoleCreateEvent{}
+	if err := json.Unmarshal([]byte(`{"guild_id":"300","role":{"id":"302","name":"mods","color":255}}`), &event); err != nil {
+		t.Fatal(err)
+	}
+	onGuildRoleCreate(session, event)
+	if len(guild.Roles()) != 2 {
+		t.Fatalf("the role was added to the guild twice: %v", guild.Roles())
+	}
+
+	if _, err := role.Edit().Name("admins").Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if role.Name() != "admins" || role.Color() != 255 {
+		t.Fatalf("the cached role was not updated: %+v", role.internal)
+	}
+
+	roles, err := guild.ReorderRoles([]RolePosition{{ID: 302, Position: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 2 || roles[1] != role || role.Position() != 1 || len(guild.Roles()) != 2 {
+		t.Fatalf("the roles were not reordered: %v", roles)
+	}
+
+	if err := role.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := guild.Role(302); exists || len(guild.Roles()) != 1 {
+		t.Fatal("the deleted role is still part of the guild")
+	}
+
+	recorder.expect(t,
+		`POST /guilds/300/roles {"name":"mods","permissions":0,"color":255,"hoist":false,"mentionable":false}`,
+		`PATCH /guilds/300/roles/302 {"name":"admins"}`,
+		`PATCH /guilds/300/roles [{"id":"302","position":1}]`,
+		`DELETE /guilds/300/roles/302`,
+	)
+}
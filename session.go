@@ -2,6 +2,8 @@ package disgo
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,9 +19,15 @@ type Session struct {
 	selfbot   bool
 	wsUrl     string
 
-	rateLimitBuckets map[string]*rateBucket
-	globalRateLimit  sync.Mutex
-	globalReset      time.Time
+	httpClient *http.Client
+	transport  http.RoundTripper
+	baseUrl    string
+
+	rateLimiter RateLimiter
+	retryPolicy RetryPolicy
+
+	// Only set if the session was created with WithIntegrationCache
+	integrations *integrationCache
 
 	shards       []*shard
 	shuttingDown bool
@@ -29,18 +37,85 @@ type Session struct {
 	game   *Game
 }
 
-func NewSelfBot(token string) (*Session, error) {
-	logger.Trace("NewSelfBot() called")
+// SessionOption can be passed to NewBot or NewSelfBot to configure the created Session
+type SessionOption func(*Session)
+
+// WithHTTPClient makes the session use the given client for all REST calls, nil keeps the default client
+func WithHTTPClient(client *http.Client) SessionOption {
+	return func(s *Session) {
+		if client == nil {
+			client = newDefaultHTTPClient()
+		}
+		s.httpClient = client
+	}
+}
+
+// WithTransport makes the session send all REST calls through the given RoundTripper.
+// It is applied after all other options, so it also applies to a client passed to WithHTTPClient, regardless of their order.
+func WithTransport(transport http.RoundTripper) SessionOption {
+	return func(s *Session) {
+		s.transport = transport
+	}
+}
+
+// WithBaseUrl makes the session send all REST calls to the given API location instead of BaseUrl
+func WithBaseUrl(baseUrl string) SessionOption {
+	return func(s *Session) {
+		s.baseUrl = strings.TrimSuffix(baseUrl, "/")
+	}
+}
+
+// WithRateLimiter replaces the in-memory rate limiter of the session, for example to share rate limits between processes
+func WithRateLimiter(limiter RateLimiter) SessionOption {
+	return func(s *Session) {
+		s.rateLimiter = limiter
+	}
+}
+
+// The default transport pools connections and keeps them alive, we just share it between all calls
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second}
+}
 
+func newSession(tokenType, token string, selfbot bool, options []SessionOption) *Session {
 	if token == "" {
 		panic("token cannot be empty")
 	}
 
-	session := &Session{tokenType: "", token: token, selfbot: true, rateLimitBuckets: make(map[string]*rateBucket)}
+	session := &Session{
+		tokenType: tokenType,
+		token:     token,
+		selfbot:   selfbot,
+
+		httpClient: newDefaultHTTPClient(),
+		baseUrl:    BaseUrl,
+
+		rateLimiter: NewMemoryRateLimiter(),
+		retryPolicy: DefaultRetryPolicy,
+	}
+
+	for _, option := range options {
+		option(session)
+	}
+
+	if session.transport != nil {
+		// Copy the client, so we never modify a client that was passed to us by WithHTTPClient
+		client := *session.httpClient
+		client.Transport = session.transport
+		session.httpClient = &client
+	}
+
+	return session
+}
+
+func NewSelfBot(token string, options ...SessionOption) (*Session, error) {
+	logger.Trace("NewSelfBot() called")
+
+	session := newSession("", token, true, options)
 	registerInternalEvents(session)
 
 	gateway := gatewayGetResponse{}
-	err := session.doHttpGet(EndPointGateway(), &gateway)
+	err := session.doHttpGet(EndPointGateway(), &gateway, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,18 +126,14 @@ func NewSelfBot(token string) (*Session, error) {
 	return session, nil
 }
 
-func NewBot(token string) (*Session, error) {
+func NewBot(token string, options ...SessionOption) (*Session, error) {
 	logger.Trace("NewBot() called")
 
-	if token == "" {
-		panic("token cannot be empty")
-	}
-
-	session := &Session{tokenType: "Bot ", token: token, rateLimitBuckets: make(map[string]*rateBucket)}
+	session := newSession("Bot ", token, false, options)
 	registerInternalEvents(session)
 
 	gateway := gatewayGetResponse{}
-	err := session.doHttpGet(EndPointBotGateway(), &gateway)
+	err := session.doHttpGet(EndPointBotGateway(), &gateway, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package disgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+type countingTransport struct {
+	calls int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSessionHTTPOptions(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The transport has to be used even though the client is set after it, and a nil client falls back to the default one
+	for _, options := range [][]SessionOption{
+		{WithTransport(nil), WithHTTPClient(nil)},
+		{WithBaseUrl(server.URL + "/"), WithHTTPClient(&http.Client{})},
+	} {
+		transport := &countingTransport{}
+		options = append(options, WithTransport(transport), WithHTTPClient(nil), WithBaseUrl(server.URL+"/api/"))
+		session := newSession("Bot ", "token", false, options)
+
+		if err := session.doHttpGet(EndPointChannel(1), nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if transport.calls != 1 {
+			t.Fatalf("expected the request to go through the transport, it was used %d times", transport.calls)
+		}
+	}
+
+	if len(paths) != 2 || paths[0] != "/api/channels/1" {
+		t.Fatalf("expected the requests to be sent to the base URL, got %v", paths)
+	}
+}
@@ -0,0 +1,53 @@
+package disgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/slf4go/logger"
+)
+
+// Discord shows the typing indicator for 10 seconds, so we trigger it again a bit before that
+var typingInterval = 8 * time.Second
+
+// typingClock paces KeepTyping, so tests can replace it
+var typingClock clock = realClock{}
+
+func (s *Session) TriggerTyping(channelID Snowflake, options ...RequestOption) error {
+	return s.doHttpPost(EndPointChannelTyping(channelID), nil, nil, options)
+}
+
+// KeepTyping shows the typing indicator in the channel until the returned function is called, or the context of the options is done.
+// Sending a message ends the indicator on Discord's side, so call stop before replying to avoid it showing up again.
+//
+//	stop := session.KeepTyping(channelID)
+//	defer stop()
+func (s *Session) KeepTyping(channelID Snowflake, options ...RequestOption) (stop func()) {
+	ctx, cancel := context.WithCancel(newRequestConfig(options).ctx)
+	options = append(options, WithContext(ctx))
+	clock := typingClock
+
+	go func() {
+		for {
+			if err := s.TriggerTyping(channelID, options...); err != nil && ctx.Err() == nil {
+				logger.Errorf("Could not trigger typing in %s: %s", channelID, err)
+			}
+
+			select {
+			case <-clock.After(typingInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return cancel
+}
+
+func (s *Channel) TriggerTyping(options ...RequestOption) error {
+	return s.session.TriggerTyping(s.ID(), options...)
+}
+
+func (s *Channel) KeepTyping(options ...RequestOption) (stop func()) {
+	return s.session.KeepTyping(s.ID(), options...)
+}
@@ -0,0 +1,51 @@
+package disgo
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tickClock only lets time pass when the test sends a tick
+type tickClock chan time.Time
+
+func (c tickClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (c tickClock) After(duration time.Duration) <-chan time.Time {
+	return c
+}
+
+func TestKeepTyping(t *testing.T) {
+	defer func(clock clock) { typingClock = clock }(typingClock)
+	ticks := make(tickClock)
+	typingClock = ticks
+
+	var stopped int32
+	triggered := make(chan struct{}, 1)
+	session, _ := newTestSession(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/channels/1/typing" || r.ContentLength != 0 {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if atomic.LoadInt32(&stopped) == 1 {
+			t.Error("typing was still triggered after stopping")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		triggered <- struct{}{}
+	})
+
+	stop := session.KeepTyping(1)
+
+	// Typing is triggered right away, and again every time the interval passes
+	<-triggered
+	for i := 0; i < 2; i++ {
+		ticks <- time.Time{}
+		<-triggered
+	}
+
+	atomic.StoreInt32(&stopped, 1)
+	stop()
+	stop()
+}